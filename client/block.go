@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ErrUnknownBlock is returned when the node has no block for a Block, such as
+// a number past the chain's head.
+var ErrUnknownBlock = errors.New("unknown block")
+
+type blockKind int
+
+const (
+	latestBlock blockKind = iota
+	finalizedBlock
+	numberBlock
+	hashBlock
+)
+
+// Block selects the block storage and runtime queries read state at. It is
+// resolved to a hash the first time a query uses it and keeps that hash, so
+// queries sharing a Block all see the same state:
+//
+//	at := client.Finalized()
+//	mg, err := runtime.GetMetagraph(c, netuid, at)
+//	infos, err := storage.GetAccountInfos(c, coldkeys, at)
+//
+// A nil *Block reads the best block of the node at every query.
+type Block struct {
+	kind   blockKind
+	number uint64
+
+	mu   sync.Mutex
+	hash *types.Hash
+}
+
+// Latest selects the best block at the time the Block is first used.
+func Latest() *Block {
+	return &Block{kind: latestBlock}
+}
+
+// Finalized selects the finalized head at the time the Block is first used.
+func Finalized() *Block {
+	return &Block{kind: finalizedBlock}
+}
+
+// AtNumber selects a block by number.
+func AtNumber(number uint64) *Block {
+	return &Block{kind: numberBlock, number: number}
+}
+
+// AtHash selects a block by hash.
+func AtHash(hash types.Hash) *Block {
+	return &Block{kind: hashBlock, hash: &hash}
+}
+
+// String describes the selection and, once resolved, the block's hash.
+func (b *Block) String() string {
+	if b == nil {
+		return "best block"
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.kind == hashBlock {
+		return fmt.Sprintf("block %s", b.hash.Hex())
+	}
+	s := b.selection()
+	if b.hash != nil {
+		s += " " + b.hash.Hex()
+	}
+	return s
+}
+
+// selection describes what b selects, without its hash
+func (b *Block) selection() string {
+	switch b.kind {
+	case latestBlock:
+		return "latest block"
+	case finalizedBlock:
+		return "finalized block"
+	case numberBlock:
+		return fmt.Sprintf("block %d", b.number)
+	}
+	return fmt.Sprintf("block %s", b.hash.Hex())
+}
+
+// BlockHash resolves b to the hash of the block it selects, asking the node
+// the first time only. It returns nil for a nil b. A Block remembers the hash
+// it resolved to, so it should not be shared between clients of different
+// chains.
+func (c *Client) BlockHash(ctx context.Context, b *Block) (*types.Hash, error) {
+	if b == nil {
+		return nil, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.hash != nil {
+		return b.hash, nil
+	}
+
+	var hashHex string
+	var err error
+	switch b.kind {
+	case latestBlock:
+		err = c.Api.Client.CallContext(ctx, &hashHex, "chain_getBlockHash")
+	case finalizedBlock:
+		if ref, ok := c.FinalizedHead(); ok {
+			b.hash = &ref.Hash
+			return b.hash, nil
+		}
+		err = c.Api.Client.CallContext(ctx, &hashHex, "chain_getFinalizedHead")
+	case numberBlock:
+		err = c.Api.Client.CallContext(ctx, &hashHex, "chain_getBlockHash", b.number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block hash: %w", err)
+	}
+	if hashHex == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBlock, b.selection())
+	}
+
+	hash, err := types.NewHashFromHexString(hashHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode block hash: %w", err)
+	}
+	b.hash = &hash
+	return b.hash, nil
+}
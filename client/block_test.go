@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestBlockResolvesOnce(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	ctx := context.Background()
+
+	hash := types.NewHash([]byte("block 100"))
+	node.Handle("chain_getBlockHash", func(params []json.RawMessage) (any, error) {
+		var number uint64
+		if len(params) == 0 {
+			return hash.Hex(), nil
+		}
+		if json.Unmarshal(params[0], &number) == nil && number == 100 {
+			return hash.Hex(), nil
+		}
+		return nil, nil
+	})
+
+	at := client.AtNumber(100)
+	got, err := c.BlockHash(ctx, at)
+	require.NoError(t, err)
+	require.Equal(t, hash, *got)
+	calls := node.MethodCalls("chain_getBlockHash")
+	got, err = c.BlockHash(ctx, at)
+	require.NoError(t, err)
+	require.Equal(t, hash, *got)
+	require.Equal(t, calls, node.MethodCalls("chain_getBlockHash"))
+
+	_, err = c.BlockHash(ctx, client.AtNumber(1<<40))
+	require.ErrorIs(t, err, client.ErrUnknownBlock)
+	require.ErrorContains(t, err, "block 1099511627776")
+
+	latest := client.Latest()
+	got, err = c.BlockHash(ctx, latest)
+	require.NoError(t, err)
+	require.Equal(t, hash, *got)
+	require.Contains(t, latest.String(), hash.Hex())
+
+	got, err = c.BlockHash(ctx, nil)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	got, err = c.BlockHash(ctx, client.AtHash(hash))
+	require.NoError(t, err)
+	require.Equal(t, hash, *got)
+}
+
+func TestUnknownLatestBlock(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	node.Respond("chain_getBlockHash", nil)
+	_, err := c.BlockHash(context.Background(), client.Latest())
+	require.ErrorIs(t, err, client.ErrUnknownBlock)
+	require.ErrorContains(t, err, "latest block")
+}
+
+func TestFinalizedBlockUsesFollowedHead(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	hash := types.NewHash([]byte("block 12"))
+	node.SetFinalizedHead(12, hash)
+	require.Eventually(t, func() bool {
+		head, ok := c.FinalizedHead()
+		return ok && head.Number == 12
+	}, 5*time.Second, 10*time.Millisecond)
+
+	calls := node.Calls()
+	got, err := c.BlockHash(context.Background(), client.Finalized())
+	require.NoError(t, err)
+	require.Equal(t, hash, *got)
+	require.Equal(t, calls, node.Calls())
+}
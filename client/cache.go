@@ -0,0 +1,141 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	gsrpcclient "github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// CallAt calls method with args followed by the hash of the block to read
+// at. A nil hash leaves it out, so the node answers at its best block.
+//
+// With WithCache, responses at a block hash are kept, since they cannot
+// change, and concurrent identical calls share one request. Calls without a
+// hash always go to the node.
+func (c *Client) CallAt(ctx context.Context, result any, method string, hash *types.Hash, args ...any) error {
+	if c.cache == nil || hash == nil {
+		return gsrpcclient.CallWithBlockHashContext(ctx, c.Api.Client, result, method, hash, args...)
+	}
+
+	params, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to encode params of %s: %w", method, err)
+	}
+	key := method + string(params) + hash.Hex()
+
+	raw, err := c.cache.get(ctx, key, func(ctx context.Context) (json.RawMessage, error) {
+		var raw json.RawMessage
+		err := gsrpcclient.CallWithBlockHashContext(ctx, c.Api.Client, &raw, method, hash, args...)
+		return raw, err
+	})
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(raw, result)
+}
+
+// cache is an LRU of raw responses bounded by entry count and size, with
+// in-flight requests shared between callers asking for the same key.
+type cache struct {
+	maxEntries int
+	maxBytes   int
+
+	mu       sync.Mutex
+	lru      *list.List
+	entries  map[string]*list.Element
+	size     int
+	inflight map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	key   string
+	value json.RawMessage
+}
+
+type cacheCall struct {
+	done  chan struct{}
+	value json.RawMessage
+	err   error
+}
+
+func newCache(maxEntries, maxBytes int) *cache {
+	return &cache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+		inflight:   map[string]*cacheCall{},
+	}
+}
+
+// get returns the value of key, calling fetch on a miss unless another
+// caller already is. Errors are not cached.
+func (c *cache) get(ctx context.Context, key string, fetch func(context.Context) (json.RawMessage, error)) (json.RawMessage, error) {
+	for {
+		c.mu.Lock()
+		if el, ok := c.entries[key]; ok {
+			c.lru.MoveToFront(el)
+			c.mu.Unlock()
+			return el.Value.(*cacheEntry).value, nil
+		}
+		call, ok := c.inflight[key]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// The caller that made the request gave up, not the node: retry
+		// with our own context.
+		if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+			continue
+		}
+		return call.value, call.err
+	}
+
+	call := &cacheCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = fetch(ctx)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.add(key, call.value)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
+
+// add stores value under key and evicts the least recently used entries
+// until both bounds hold again. Values larger than maxBytes are not kept.
+func (c *cache) add(key string, value json.RawMessage) {
+	if c.maxBytes > 0 && len(value) > c.maxBytes {
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value})
+	c.size += len(value)
+
+	for (c.maxEntries > 0 && c.lru.Len() > c.maxEntries) || (c.maxBytes > 0 && c.size > c.maxBytes) {
+		el := c.lru.Back()
+		e := el.Value.(*cacheEntry)
+		c.lru.Remove(el)
+		delete(c.entries, e.key)
+		c.size -= len(e.value)
+	}
+}
@@ -0,0 +1,86 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+// echoNode answers test_echo with its first param after a short delay, so
+// that concurrent calls overlap.
+func echoNode(t *testing.T) *testutils.FakeNode {
+	node := testutils.NewFakeNode(t)
+	node.Handle("test_echo", func(params []json.RawMessage) (any, error) {
+		time.Sleep(50 * time.Millisecond)
+		var v string
+		return v, json.Unmarshal(params[0], &v)
+	})
+	return node
+}
+
+func TestCallAtCollapsesConcurrentCalls(t *testing.T) {
+	node := echoNode(t)
+	c := node.NewClient(client.WithCache(10, 0))
+	hash := types.NewHash([]byte("block 1"))
+
+	var wg sync.WaitGroup
+	res := make([]string, 8)
+	errs := make([]error, len(res))
+	for i := range res {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.CallAt(context.Background(), &res[i], "test_echo", &hash, "a")
+		}()
+	}
+	wg.Wait()
+	for i := range res {
+		require.NoError(t, errs[i])
+		require.Equal(t, "a", res[i])
+	}
+	require.EqualValues(t, 1, node.MethodCalls("test_echo"))
+
+	var again string
+	require.NoError(t, c.CallAt(context.Background(), &again, "test_echo", &hash, "a"))
+	require.Equal(t, "a", again)
+	require.EqualValues(t, 1, node.MethodCalls("test_echo"))
+
+	// other params and other blocks are other entries
+	other := types.NewHash([]byte("block 2"))
+	require.NoError(t, c.CallAt(context.Background(), &again, "test_echo", &hash, "b"))
+	require.NoError(t, c.CallAt(context.Background(), &again, "test_echo", &other, "a"))
+	require.EqualValues(t, 3, node.MethodCalls("test_echo"))
+}
+
+func TestCallAtLatestNotCached(t *testing.T) {
+	node := echoNode(t)
+	c := node.NewClient(client.WithCache(10, 0))
+
+	var res string
+	for range 3 {
+		require.NoError(t, c.CallAt(context.Background(), &res, "test_echo", nil, "a"))
+		require.Equal(t, "a", res)
+	}
+	require.EqualValues(t, 3, node.MethodCalls("test_echo"))
+}
+
+func TestCallAtEvictsLeastRecentlyUsed(t *testing.T) {
+	node := echoNode(t)
+	c := node.NewClient(client.WithCache(2, 0))
+	hash := types.NewHash([]byte("block 1"))
+
+	var res string
+	for _, v := range []string{"a", "b", "a", "c", "a", "b"} {
+		require.NoError(t, c.CallAt(context.Background(), &res, "test_echo", &hash, v))
+		require.Equal(t, v, res)
+	}
+	// b was evicted by c, a stayed in use
+	require.EqualValues(t, 4, node.MethodCalls("test_echo"))
+}
@@ -1,42 +1,210 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/transport"
 )
 
 type Client struct {
-	Api     *gsrpc.SubstrateAPI
+	Api *gsrpc.SubstrateAPI
+	// Meta is the metadata fetched when the client connected. It is not
+	// refreshed after a runtime upgrade.
+	//
+	// Deprecated: use Metadata()
 	Meta    *types.Metadata
 	keyring *signature.KeyringPair
 	Network uint16
+
+	endpoints       []string
+	backoff         Backoff
+	dialer          transport.Dialer
+	conn            *conn
+	cache           *cache
+	runtime         atomic.Pointer[runtimeState]
+	finalized       atomic.Pointer[BlockRef]
+	fetchedHead     atomic.Pointer[fetchedHead]
+	upgradeMu       sync.Mutex
+	hooksMu         sync.Mutex
+	upgradeHooks    []RuntimeUpgradeHook
+	done            chan struct{}
+	closeOnce       sync.Once
+	wg              sync.WaitGroup
+	offline         bool
+	genesisHash     types.Hash
+	expectedGenesis types.Hash
+}
+
+// RuntimeUpgradeHook is called after the client swapped in the metadata of a
+// new runtime. Hooks are called one at a time, in registration order.
+type RuntimeUpgradeHook func(prev, next types.RuntimeVersion)
+
+// runtimeState pairs metadata with the runtime version it belongs to so both
+// are swapped together.
+type runtimeState struct {
+	meta    *types.Metadata
+	version types.RuntimeVersion
 }
 
 func NewClient(endpoint string, opts ...Option) (*Client, error) {
 	client := &Client{
-		Network: 42,
+		Network:   42,
+		endpoints: []string{endpoint},
+		backoff:   DefaultBackoff,
+		dialer:    transport.Dial,
+		done:      make(chan struct{}),
 	}
 
 	for _, opt := range opts {
 		opt(client)
 	}
 
-	api, err := gsrpc.NewSubstrateAPI(endpoint)
+	cn, err := dial(context.Background(), client.endpoints, client.backoff, client.dialer, client.expectedGenesis)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to subtensor node: %w", err)
 	}
+	client.conn = cn
 
-	client.Api = api
+	r, err := rpc.NewRPC(cn)
+	if err != nil {
+		cn.Close()
+		return nil, fmt.Errorf("failed to connect to subtensor node: %w", err)
+	}
+
+	client.Api = &gsrpc.SubstrateAPI{RPC: r, Client: cn}
 
-	meta, err := api.RPC.State.GetMetadataLatest()
+	meta, err := r.State.GetMetadataLatest()
 	if err != nil {
+		cn.Close()
 		return nil, fmt.Errorf("failed to get metadata from subtensor node: %w", err)
 	}
 
+	rv, err := r.State.GetRuntimeVersionLatest()
+	if err != nil {
+		cn.Close()
+		return nil, fmt.Errorf("failed to get runtime version from subtensor node: %w", err)
+	}
+
 	client.Meta = meta
+	client.runtime.Store(&runtimeState{meta: meta, version: *rv})
+	cn.onReconnect = client.checkRuntimeVersion
+
+	client.wg.Add(2)
+	go client.watchRuntimeVersion()
+	go client.watchFinalizedHeads()
 
 	return client, nil
 }
+
+// Metadata returns the metadata currently in use by the client. It is safe
+// for concurrent use and is swapped atomically after a runtime upgrade.
+func (c *Client) Metadata() *types.Metadata {
+	return c.runtime.Load().meta
+}
+
+// RuntimeVersion returns the runtime version matching Metadata. It is kept up
+// to date by the runtime upgrade watcher and costs no RPC.
+func (c *Client) RuntimeVersion() types.RuntimeVersion {
+	return c.runtime.Load().version
+}
+
+// Endpoint returns the endpoint the client is currently connected to, or an
+// empty string for an offline client.
+func (c *Client) Endpoint() string {
+	if c.conn == nil {
+		return ""
+	}
+	return c.conn.URL()
+}
+
+// Close stops the runtime watcher and closes the underlying connection. The
+// client is unusable afterwards.
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		if c.conn != nil {
+			c.conn.Close()
+		}
+		c.wg.Wait()
+	})
+}
+
+// Done is closed once Close has been called
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// watchRuntimeVersion follows state_subscribeRuntimeVersion for the lifetime
+// of the client.
+func (c *Client) watchRuntimeVersion() {
+	defer c.wg.Done()
+	follow(c, "state", "RuntimeVersion", "runtimeVersion", func(rv types.RuntimeVersion) {
+		// On failure the version is not recorded, so the next
+		// notification or reconnect retries the refresh.
+		_ = c.applyRuntimeVersion(rv)
+	}, nil)
+}
+
+// follow subscribes to <namespace>_subscribe<name> and passes every
+// notification to handle until the client is closed, resubscribing when the
+// subscription drops. lost, if set, is called whenever it does. Transports
+// without subscriptions end it right away.
+func follow[T any](c *Client, namespace, name, notification string, handle func(T), lost func()) {
+	followFrom(c, nil, subscriber[T](c, namespace, name, notification, nil), nil, nil, handle, lost)
+}
+
+// checkRuntimeVersion catches upgrades that happened while disconnected.
+func (c *Client) checkRuntimeVersion() error {
+	return c.RefreshRuntimeVersion(context.Background())
+}
+
+// RefreshRuntimeVersion asks the node for its runtime version and switches to
+// the new metadata if it changed. Clients on a transport without
+// subscriptions, such as HTTP, only learn about upgrades this way or after a
+// reconnect.
+func (c *Client) RefreshRuntimeVersion(ctx context.Context) error {
+	if c.offline {
+		return ErrOffline
+	}
+	var rv types.RuntimeVersion
+	if err := c.Api.Client.CallContext(ctx, &rv, "state_getRuntimeVersion"); err != nil {
+		return fmt.Errorf("failed to get runtime version: %w", err)
+	}
+	return c.applyRuntimeVersion(rv)
+}
+
+// applyRuntimeVersion fetches fresh metadata and runs the upgrade hooks if rv
+// differs from the runtime version currently in use.
+func (c *Client) applyRuntimeVersion(rv types.RuntimeVersion) error {
+	c.upgradeMu.Lock()
+	prev := c.runtime.Load()
+	if prev.version.SpecVersion == rv.SpecVersion && prev.version.TransactionVersion == rv.TransactionVersion {
+		c.upgradeMu.Unlock()
+		return nil
+	}
+
+	meta, err := c.Api.RPC.State.GetMetadataLatest()
+	if err != nil {
+		c.upgradeMu.Unlock()
+		return fmt.Errorf("failed to refresh metadata: %w", err)
+	}
+	c.runtime.Store(&runtimeState{meta: meta, version: rv})
+
+	// Hooks run outside upgradeMu so they are free to use the client, but
+	// hooksMu is taken before releasing it so that the hooks of back to back
+	// upgrades run in the order the upgrades were applied.
+	c.hooksMu.Lock()
+	c.upgradeMu.Unlock()
+	defer c.hooksMu.Unlock()
+	for _, hook := range c.upgradeHooks {
+		hook(prev.version, rv)
+	}
+	return nil
+}
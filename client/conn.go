@@ -0,0 +1,321 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/gorilla/websocket"
+	"github.com/subtrahend-labs/gobt/transport"
+)
+
+// Backoff controls how reconnect attempts are spaced out after the
+// connection to a node is lost.
+type Backoff struct {
+	// Initial is the delay before the second attempt. The first attempt is
+	// made immediately.
+	Initial time.Duration
+	// Max caps the delay between two attempts.
+	Max time.Duration
+	// MaxAttempts is the number of dials made before giving up. Zero means
+	// retry until the context of the failing call is done.
+	MaxAttempts int
+}
+
+var DefaultBackoff = Backoff{
+	Initial:     250 * time.Millisecond,
+	Max:         10 * time.Second,
+	MaxAttempts: 8,
+}
+
+func (b Backoff) delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 1; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// conn is a gsrpc client that transparently redials when the connection to
+// the node drops, rotating through the configured endpoints.
+type conn struct {
+	endpoints []string
+	backoff   Backoff
+	dialer    transport.Dialer
+	// expected genesis hash, zero accepts any chain
+	expectedGenesis types.Hash
+
+	mu      sync.RWMutex
+	cl      transport.Transport
+	genesis types.Hash
+	current int
+	gen     uint64
+	closed  bool
+
+	// serializes reconnects so that concurrent failing calls redial once
+	reconnectMu sync.Mutex
+
+	onReconnect func() error
+}
+
+func dial(ctx context.Context, endpoints []string, backoff Backoff, dialer transport.Dialer, expectedGenesis types.Hash) (*conn, error) {
+	c := &conn{
+		endpoints:       endpoints,
+		backoff:         backoff,
+		dialer:          dialer,
+		expectedGenesis: expectedGenesis,
+		current:         len(endpoints) - 1,
+	}
+	if err := c.redial(ctx, true); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// dialEndpoint connects to endpoint and checks that it serves the expected
+// chain, returning the node's genesis hash.
+func (c *conn) dialEndpoint(ctx context.Context, endpoint string) (transport.Transport, types.Hash, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.Default().DialTimeout)
+	defer cancel()
+
+	cl, err := c.dialer(ctx, endpoint)
+	if err != nil {
+		return nil, types.Hash{}, err
+	}
+
+	var genesisHex string
+	if err := cl.CallContext(ctx, &genesisHex, "chain_getBlockHash", 0); err != nil {
+		cl.Close()
+		return nil, types.Hash{}, fmt.Errorf("failed to get genesis hash: %w", err)
+	}
+	genesis, err := types.NewHashFromHexString(genesisHex)
+	if err != nil {
+		cl.Close()
+		return nil, types.Hash{}, fmt.Errorf("failed to decode genesis hash: %w", err)
+	}
+	if err := checkGenesis(c.expectedGenesis, genesis); err != nil {
+		cl.Close()
+		return nil, types.Hash{}, err
+	}
+
+	return cl, genesis, nil
+}
+
+// redial dials the endpoints in order, starting after the current one, until
+// one succeeds or the backoff policy is exhausted. first is set for the
+// initial connect, when there is nothing to reconnect to.
+func (c *conn) redial(ctx context.Context, first bool) error {
+	action := "reconnect"
+	if first {
+		action = "connect"
+	}
+	var lastErr error
+	for attempt := 0; c.backoff.MaxAttempts == 0 || attempt < c.backoff.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(c.backoff.delay(attempt)):
+			case <-ctx.Done():
+				return fmt.Errorf("%s aborted: %w (last error: %v)", action, ctx.Err(), lastErr)
+			}
+		}
+
+		c.mu.RLock()
+		idx := (c.current + 1) % len(c.endpoints)
+		c.mu.RUnlock()
+
+		cl, genesis, err := c.dialEndpoint(ctx, c.endpoints[idx])
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", c.endpoints[idx], err)
+			c.mu.Lock()
+			c.current = idx
+			c.mu.Unlock()
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			cl.Close()
+			return gethrpc.ErrClientQuit
+		}
+		old := c.cl
+		c.cl = cl
+		c.genesis = genesis
+		c.current = idx
+		c.gen++
+		c.mu.Unlock()
+
+		if old != nil {
+			old.Close()
+		}
+		return nil
+	}
+	return fmt.Errorf("failed to %s after %d attempts: %w", action, c.backoff.MaxAttempts, lastErr)
+}
+
+func (c *conn) get() (transport.Transport, uint64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cl, c.gen
+}
+
+// reconnect redials unless another caller already replaced the connection
+// that failed at generation gen.
+func (c *conn) reconnect(ctx context.Context, gen uint64) error {
+	c.reconnectMu.Lock()
+	c.mu.RLock()
+	stale := c.gen != gen
+	closed := c.closed
+	c.mu.RUnlock()
+	if closed {
+		c.reconnectMu.Unlock()
+		return gethrpc.ErrClientQuit
+	}
+	if stale {
+		c.reconnectMu.Unlock()
+		return nil
+	}
+
+	err := c.redial(ctx, false)
+	c.reconnectMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Best effort: on failure the previous metadata stays in use.
+	if c.onReconnect != nil {
+		_ = c.onReconnect()
+	}
+	return nil
+}
+
+func (c *conn) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *conn) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	cl, gen := c.get()
+	err := cl.CallContext(ctx, result, method, args...)
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return contextError(ctxErr, err)
+	}
+	if !isConnectionError(err) {
+		return err
+	}
+
+	if rerr := c.reconnect(ctx, gen); rerr != nil {
+		return fmt.Errorf("%w (reconnect failed: %w)", err, rerr)
+	}
+
+	// Submissions are not retried, the node may already have received them.
+	if strings.HasPrefix(method, "author_") {
+		return err
+	}
+
+	cl, _ = c.get()
+	return cl.CallContext(ctx, result, method, args...)
+}
+
+func (c *conn) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	cl, gen := c.get()
+	sub, err := cl.Subscribe(ctx, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix, channel, args...)
+	if err == nil {
+		return sub, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, contextError(ctxErr, err)
+	}
+	if !isConnectionError(err) {
+		return nil, err
+	}
+
+	if rerr := c.reconnect(ctx, gen); rerr != nil {
+		return nil, fmt.Errorf("%w (reconnect failed: %w)", err, rerr)
+	}
+
+	// Submissions are not retried, the node may already have received them.
+	if namespace == "author" {
+		return nil, err
+	}
+
+	cl, _ = c.get()
+	return cl.Subscribe(ctx, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix, channel, args...)
+}
+
+func (c *conn) genesisHash() types.Hash {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.genesis
+}
+
+func (c *conn) URL() string {
+	cl, _ := c.get()
+	return cl.URL()
+}
+
+func (c *conn) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.cl.Close()
+}
+
+// contextError makes sure err matches ctxErr with errors.Is. A deadline can
+// surface as a socket timeout rather than the context error itself.
+func contextError(ctxErr, err error) error {
+	if errors.Is(err, ctxErr) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ctxErr, err)
+}
+
+// isConnectionError reports whether err means the connection to the node is
+// gone, as opposed to an error returned by the node or the caller's context.
+func isConnectionError(err error) bool {
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var closeErr *websocket.CloseError
+	var netErr net.Error
+	return errors.Is(err, gethrpc.ErrClientQuit) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, websocket.ErrBadHandshake) ||
+		errors.Is(err, websocket.ErrCloseSent) ||
+		errors.As(err, &closeErr) ||
+		errors.As(err, &netErr)
+}
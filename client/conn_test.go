@@ -0,0 +1,136 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+var testBackoff = client.Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, MaxAttempts: 20}
+
+func newNode(t *testing.T) *testutils.FakeNode {
+	node := testutils.NewFakeNode(t)
+	node.Handle("chain_getBlockHash", func([]json.RawMessage) (any, error) {
+		return types.NewHash([]byte{1}).Hex(), nil
+	})
+	node.Handle("system_chain", func([]json.RawMessage) (any, error) {
+		return node.URL(), nil
+	})
+	return node
+}
+
+func TestReconnectSameEndpoint(t *testing.T) {
+	node := newNode(t)
+
+	c, err := client.NewClient(node.URL(), client.WithBackoff(testBackoff))
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Api.RPC.Chain.GetBlockHash(0)
+	require.NoError(t, err)
+
+	node.Kill()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		node.Restart()
+	}()
+
+	_, err = c.Api.RPC.Chain.GetBlockHash(0)
+	require.NoError(t, err, "call should succeed once the node is back")
+	require.Equal(t, node.URL(), c.Endpoint())
+	require.NotNil(t, c.Metadata())
+}
+
+func TestReconnectRotatesEndpoints(t *testing.T) {
+	primary := newNode(t)
+	fallback := newNode(t)
+
+	c, err := client.NewClient(primary.URL(), client.WithEndpoints(fallback.URL()), client.WithBackoff(testBackoff))
+	require.NoError(t, err)
+	defer c.Close()
+	require.Equal(t, primary.URL(), c.Endpoint())
+
+	primary.Kill()
+	before := fallback.Calls()
+
+	var chain types.Text
+	err = c.Api.Client.Call(&chain, "system_chain")
+	require.NoError(t, err)
+	require.Equal(t, fallback.URL(), string(chain))
+	require.Equal(t, fallback.URL(), c.Endpoint())
+	require.Greater(t, fallback.Calls(), before)
+}
+
+func TestReconnectGivesUp(t *testing.T) {
+	node := newNode(t)
+
+	c, err := client.NewClient(node.URL(), client.WithBackoff(client.Backoff{Initial: time.Millisecond, Max: time.Millisecond, MaxAttempts: 3}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	node.Kill()
+
+	_, err = c.Api.RPC.Chain.GetBlockHash(0)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "reconnect failed"), err.Error())
+	require.ErrorContains(t, err, "failed to reconnect after 3 attempts")
+}
+
+func TestSubmitAndWatchNotRetried(t *testing.T) {
+	node := newNode(t)
+
+	c, err := client.NewClient(node.URL(), client.WithBackoff(testBackoff))
+	require.NoError(t, err)
+	defer c.Close()
+
+	node.Kill()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		node.Restart()
+	}()
+
+	ch := make(chan json.RawMessage)
+	_, err = c.Api.Client.Subscribe(context.Background(), "author", "submitAndWatchExtrinsic", "unwatchExtrinsic", "extrinsicUpdate", ch, "0x00")
+	require.Error(t, err)
+	require.Equal(t, node.URL(), c.Endpoint(), "the client reconnects")
+	require.Zero(t, node.MethodCalls("author_submitAndWatchExtrinsic"), "the extrinsic is not sent again")
+}
+
+func TestConnectGivesUp(t *testing.T) {
+	node := newNode(t)
+	node.Kill()
+
+	_, err := client.NewClient(node.URL(), client.WithBackoff(client.Backoff{Initial: time.Millisecond, Max: time.Millisecond, MaxAttempts: 2}))
+	require.ErrorContains(t, err, "failed to connect after 2 attempts")
+}
+
+func TestConcurrentCallsReconnectOnce(t *testing.T) {
+	node := newNode(t)
+
+	c, err := client.NewClient(node.URL(), client.WithBackoff(testBackoff))
+	require.NoError(t, err)
+	defer c.Close()
+
+	node.Kill()
+	node.Restart()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Api.RPC.Chain.GetBlockHash(0)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, node.URL(), c.Endpoint())
+}
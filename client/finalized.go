@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// BlockRef identifies a block by number and hash.
+type BlockRef struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// FinalizedHead returns the latest finalized block the client has seen,
+// without an RPC. It reports false while the client is not following
+// finalized heads: before the first one arrives, while reconnecting, on
+// transports without subscriptions and for offline clients.
+func (c *Client) FinalizedHead() (BlockRef, bool) {
+	ref := c.finalized.Load()
+	if ref == nil {
+		return BlockRef{}, false
+	}
+	return *ref, true
+}
+
+// fetchedHead is a finalized head looked up with an RPC
+type fetchedHead struct {
+	ref BlockRef
+	at  time.Time
+}
+
+// FinalizedHeadContext returns the finalized head the client follows or,
+// when it follows none, the head it last looked up as long as that was at
+// most maxAge ago. Otherwise it asks the node and keeps the answer for the
+// next call. It also returns how long ago the head was looked up, zero for a
+// followed head.
+func (c *Client) FinalizedHeadContext(ctx context.Context, maxAge time.Duration) (BlockRef, time.Duration, error) {
+	if ref, ok := c.FinalizedHead(); ok {
+		return ref, 0, nil
+	}
+	if f := c.fetchedHead.Load(); f != nil {
+		if age := time.Since(f.at); age <= maxAge {
+			return f.ref, age, nil
+		}
+	}
+
+	var hashHex string
+	if err := c.Api.Client.CallContext(ctx, &hashHex, "chain_getFinalizedHead"); err != nil {
+		return BlockRef{}, 0, fmt.Errorf("failed to get finalized head: %w", err)
+	}
+	hash, err := types.NewHashFromHexString(hashHex)
+	if err != nil {
+		return BlockRef{}, 0, fmt.Errorf("failed to decode finalized head: %w", err)
+	}
+	var header types.Header
+	if err := c.Api.Client.CallContext(ctx, &header, "chain_getHeader", hashHex); err != nil {
+		return BlockRef{}, 0, fmt.Errorf("failed to get finalized header: %w", err)
+	}
+	ref := BlockRef{Number: uint64(header.Number), Hash: hash}
+	c.fetchedHead.Store(&fetchedHead{ref: ref, at: time.Now()})
+	return ref, 0, nil
+}
+
+// watchFinalizedHeads follows chain_subscribeFinalizedHeads for the lifetime
+// of the client. Notifications only carry the header, so the hash is looked
+// up once per block here rather than by every reader.
+func (c *Client) watchFinalizedHeads() {
+	defer c.wg.Done()
+	follow(c, "chain", "FinalizedHeads", "finalizedHead", func(header types.Header) {
+		number := uint64(header.Number)
+		var hashHex string
+		// bounded: a call in flight when the connection drops may never be
+		// answered, and would block every later notification
+		ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+		err := c.Api.Client.CallContext(ctx, &hashHex, "chain_getBlockHash", number)
+		cancel()
+		if err != nil {
+			c.finalized.Store(nil)
+			return
+		}
+		hash, err := types.NewHashFromHexString(hashHex)
+		if err != nil {
+			c.finalized.Store(nil)
+			return
+		}
+		c.finalized.Store(&BlockRef{Number: number, Hash: hash})
+	}, func() {
+		c.finalized.Store(nil)
+	})
+}
@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestFinalizedHeadFollowed(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	require.Eventually(t, func() bool {
+		head, ok := c.FinalizedHead()
+		return ok && head == client.BlockRef{Number: 0, Hash: testutils.FakeGenesisHash}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	hash := types.NewHash([]byte("block 12"))
+	node.SetFinalizedHead(12, hash)
+	require.Eventually(t, func() bool {
+		head, ok := c.FinalizedHead()
+		return ok && head == client.BlockRef{Number: 12, Hash: hash}
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFinalizedHeadOverHTTP(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+
+	c, err := client.NewClient(node.HTTPURL())
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, ok := c.FinalizedHead()
+	require.False(t, ok)
+
+	hash := types.NewHash([]byte("block 12"))
+	node.SetFinalizedHead(12, hash)
+	head, age, err := c.FinalizedHeadContext(context.Background(), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, client.BlockRef{Number: 12, Hash: hash}, head)
+	require.Zero(t, age)
+
+	// kept until it is older than maxAge
+	node.SetFinalizedHead(13, types.NewHash([]byte("block 13")))
+	calls := node.Calls()
+	head, age, err = c.FinalizedHeadContext(context.Background(), time.Hour)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, head.Number)
+	require.Positive(t, age)
+	require.Equal(t, calls, node.Calls())
+
+	head, _, err = c.FinalizedHeadContext(context.Background(), 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 13, head.Number)
+}
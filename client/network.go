@@ -0,0 +1,102 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+// ErrGenesisMismatch is returned when a node does not serve the chain the
+// client expects.
+var ErrGenesisMismatch = errors.New("genesis hash mismatch")
+
+// Network describes a subtensor chain: where to reach it, how its addresses
+// are formatted and which genesis block identifies it.
+type Network struct {
+	Name       string
+	Endpoints  []string
+	SS58Prefix uint16
+	// GenesisHash is checked against every node the client connects to. A
+	// zero hash disables the check.
+	GenesisHash types.Hash
+}
+
+var finneyGenesis = mustHash("0x2f0555cc76fc2840a25a6ea3b9637146806f1f44b090c175ffde2a7e5ab36c03")
+
+var (
+	Finney = Network{
+		Name:        "finney",
+		Endpoints:   []string{"wss://entrypoint-finney.opentensor.ai:443"},
+		SS58Prefix:  42,
+		GenesisHash: finneyGenesis,
+	}
+	// Archive serves the finney chain with full history
+	Archive = Network{
+		Name:        "archive",
+		Endpoints:   []string{"wss://archive.chain.opentensor.ai:443"},
+		SS58Prefix:  42,
+		GenesisHash: finneyGenesis,
+	}
+	// Testnet has been reset in the past, so its genesis is not pinned
+	Testnet = Network{
+		Name:       "test",
+		Endpoints:  []string{"wss://test.finney.opentensor.ai:443"},
+		SS58Prefix: 42,
+	}
+	// Local is a development node, its genesis changes with every fresh chain
+	Local = Network{
+		Name:       "local",
+		Endpoints:  []string{"ws://127.0.0.1:9944"},
+		SS58Prefix: 42,
+	}
+)
+
+// NetworkByName looks up a preset by the names used by the bittensor tooling:
+// finney, test, local and archive.
+func NetworkByName(name string) (Network, bool) {
+	for _, n := range []Network{Finney, Archive, Testnet, Local} {
+		if n.Name == name {
+			return n, true
+		}
+	}
+	return Network{}, false
+}
+
+// NewNetworkClient connects to the first reachable endpoint of the preset and
+// fails with ErrGenesisMismatch if the node serves another chain.
+func NewNetworkClient(n Network, opts ...Option) (*Client, error) {
+	if len(n.Endpoints) == 0 {
+		return nil, fmt.Errorf("network %q has no endpoints", n.Name)
+	}
+	opts = append([]Option{WithEndpoints(n.Endpoints[1:]...), withNetworkPreset(n)}, opts...)
+	return NewClient(n.Endpoints[0], opts...)
+}
+
+func withNetworkPreset(n Network) Option {
+	return func(c *Client) {
+		c.Network = n.SS58Prefix
+		c.expectedGenesis = n.GenesisHash
+	}
+}
+
+// SS58 formats an account with the client's network prefix.
+func (c *Client) SS58(acc types.AccountID) string {
+	return typetools.AccountIDToSS58WithPrefix(acc, c.Network)
+}
+
+func mustHash(hex string) types.Hash {
+	h, err := types.NewHashFromHexString(hex)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+func checkGenesis(expected, got types.Hash) error {
+	if expected == (types.Hash{}) || expected == got {
+		return nil
+	}
+	return fmt.Errorf("%w: expected %s, node has %s", ErrGenesisMismatch, expected.Hex(), got.Hex())
+}
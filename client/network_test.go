@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestNetworkByName(t *testing.T) {
+	for _, name := range []string{"finney", "test", "local", "archive"} {
+		n, ok := client.NetworkByName(name)
+		require.True(t, ok, name)
+		require.Equal(t, name, n.Name)
+		require.NotEmpty(t, n.Endpoints)
+	}
+	_, ok := client.NetworkByName("mainnet")
+	require.False(t, ok)
+	require.Equal(t, client.Finney.GenesisHash, client.Archive.GenesisHash)
+}
+
+func TestNetworkClientGenesisCheck(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+
+	n := client.Network{Name: "fake", Endpoints: []string{node.URL()}, SS58Prefix: 42, GenesisHash: testutils.FakeGenesisHash}
+	c, err := client.NewNetworkClient(n)
+	require.NoError(t, err)
+	require.Equal(t, testutils.FakeGenesisHash, c.GenesisHash())
+	c.Close()
+
+	n.GenesisHash = client.Finney.GenesisHash
+	_, err = client.NewNetworkClient(n, client.WithBackoff(client.Backoff{MaxAttempts: 1}))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, client.ErrGenesisMismatch), err.Error())
+}
+
+func TestFailoverSkipsWrongChain(t *testing.T) {
+	primary := testutils.NewFakeNode(t)
+	wrong := testutils.NewFakeNode(t)
+	wrong.Handle("chain_getBlockHash", func([]json.RawMessage) (any, error) { return types.NewHash([]byte("other chain")).Hex(), nil })
+	right := testutils.NewFakeNode(t)
+
+	c, err := client.NewClient(primary.URL(),
+		client.WithEndpoints(wrong.URL(), right.URL()),
+		client.WithExpectedGenesis(testutils.FakeGenesisHash),
+		client.WithBackoff(testBackoff),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	primary.Kill()
+	_, err = c.Api.RPC.State.GetRuntimeVersionLatest()
+	require.NoError(t, err)
+	require.Equal(t, right.URL(), c.Endpoint())
+}
+
+func TestSS58UsesClientNetwork(t *testing.T) {
+	c, err := client.NewOfflineClient(testMetadata(t), client.OfflineParams{}, client.WithNetwork(0))
+	require.NoError(t, err)
+
+	acc, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+	require.Equal(t, "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5", c.SS58(*acc))
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/beefy"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/mmr"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/offchain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ErrOffline is returned by every RPC made through an offline client.
+var ErrOffline = errors.New("client is offline")
+
+// OfflineParams are the chain constants that signing needs on top of the
+// metadata. They must match the runtime the metadata was exported from.
+type OfflineParams struct {
+	GenesisHash        types.Hash `json:"genesisHash"`
+	SpecVersion        types.U32  `json:"specVersion"`
+	TransactionVersion types.U32  `json:"transactionVersion"`
+}
+
+// NewOfflineClient builds a client that never connects to a node. Call and
+// extrinsic builders and sigtools work as usual, provided the nonce is set
+// explicitly when signing. Storage and runtime queries fail with ErrOffline.
+func NewOfflineClient(meta *types.Metadata, params OfflineParams, opts ...Option) (*Client, error) {
+	if meta == nil {
+		return nil, fmt.Errorf("metadata is required for an offline client")
+	}
+
+	client := &Client{
+		Network: 42,
+		done:    make(chan struct{}),
+		offline: true,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	types.SetSerDeOptions(types.SerDeOptionsFromMetadata(meta))
+
+	cl := offlineConn{}
+	client.Api = &gsrpc.SubstrateAPI{
+		RPC: &rpc.RPC{
+			Author:   author.NewAuthor(cl),
+			Beefy:    beefy.NewBeefy(cl),
+			Chain:    chain.NewChain(cl),
+			MMR:      mmr.NewMMR(cl),
+			Offchain: offchain.NewOffchain(cl),
+			State:    state.NewState(cl),
+			System:   system.NewSystem(cl),
+		},
+		Client: cl,
+	}
+	client.Meta = meta
+	client.genesisHash = params.GenesisHash
+	client.runtime.Store(&runtimeState{
+		meta: meta,
+		version: types.RuntimeVersion{
+			APIs:               []types.RuntimeVersionAPI{},
+			SpecVersion:        params.SpecVersion,
+			TransactionVersion: params.TransactionVersion,
+		},
+	})
+
+	return client, nil
+}
+
+// LoadOfflineClient reads the files written by cmd/offline and builds an
+// offline client from them.
+func LoadOfflineClient(metadataPath, paramsPath string, opts ...Option) (*Client, error) {
+	meta, err := ReadMetadataFile(metadataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := os.ReadFile(paramsPath) // #nosec G304 -- path is chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline params: %w", err)
+	}
+	var params OfflineParams
+	if err := json.Unmarshal(bz, &params); err != nil {
+		return nil, fmt.Errorf("failed to decode offline params: %w", err)
+	}
+
+	return NewOfflineClient(meta, params, opts...)
+}
+
+// Offline reports whether the client was built with NewOfflineClient.
+func (c *Client) Offline() bool {
+	return c.offline
+}
+
+// GenesisHash returns the genesis hash of the chain the client serves. It is
+// read whenever the client connects to a node, or given to NewOfflineClient.
+func (c *Client) GenesisHash() types.Hash {
+	if c.conn != nil {
+		return c.conn.genesisHash()
+	}
+	return c.genesisHash
+}
+
+// ReadMetadataFile reads metadata saved by WriteMetadataFile. Both the hex
+// form returned by state_getMetadata and raw SCALE bytes are accepted.
+func ReadMetadataFile(path string) (*types.Metadata, error) {
+	bz, err := os.ReadFile(path) // #nosec G304 -- path is chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	bz = bytes.TrimSpace(bz)
+	if bytes.HasPrefix(bz, []byte("0x")) {
+		bz, err = codec.HexDecodeString(string(bz))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode metadata hex: %w", err)
+		}
+	}
+
+	var meta types.Metadata
+	if err := codec.Decode(bz, &meta); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	return &meta, nil
+}
+
+// WriteMetadataFile saves metadata as hex, the same encoding state_getMetadata
+// uses.
+func WriteMetadataFile(path string, meta *types.Metadata) error {
+	enc, err := codec.EncodeToHex(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(enc), 0o600); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
+
+// offlineConn is the gsrpc client behind an offline Client
+type offlineConn struct{}
+
+func (offlineConn) Call(interface{}, string, ...interface{}) error {
+	return ErrOffline
+}
+
+func (offlineConn) CallContext(context.Context, interface{}, string, ...interface{}) error {
+	return ErrOffline
+}
+
+func (offlineConn) Subscribe(context.Context, string, string, string, string, interface{}, ...interface{}) (*gethrpc.ClientSubscription, error) {
+	return nil, ErrOffline
+}
+
+func (offlineConn) URL() string {
+	return ""
+}
+
+func (offlineConn) Close() {}
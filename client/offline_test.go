@@ -0,0 +1,71 @@
+package client_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+func testMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+	require.NoError(t, codec.DecodeFromHex(types.MetadataV14Data, &meta))
+	return &meta
+}
+
+func TestOfflineClient(t *testing.T) {
+	params := client.OfflineParams{
+		GenesisHash:        types.NewHash([]byte{0xde, 0xad}),
+		SpecVersion:        250,
+		TransactionVersion: 3,
+	}
+	c, err := client.NewOfflineClient(testMetadata(t), params, client.WithNetwork(1))
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.True(t, c.Offline())
+	require.Equal(t, uint16(1), c.Network)
+	require.Equal(t, params.GenesisHash, c.GenesisHash())
+	require.Equal(t, params.SpecVersion, c.RuntimeVersion().SpecVersion)
+	require.Equal(t, params.TransactionVersion, c.RuntimeVersion().TransactionVersion)
+	require.Empty(t, c.Endpoint())
+
+	_, err = c.Api.RPC.Chain.GetBlockHashLatest()
+	require.True(t, errors.Is(err, client.ErrOffline), "queries should fail with ErrOffline")
+}
+
+func TestLoadOfflineClient(t *testing.T) {
+	dir := t.TempDir()
+	metaPath := filepath.Join(dir, "metadata.hex")
+	paramsPath := filepath.Join(dir, "params.json")
+
+	meta := testMetadata(t)
+	require.NoError(t, client.WriteMetadataFile(metaPath, meta))
+
+	params := client.OfflineParams{GenesisHash: types.NewHash([]byte{1}), SpecVersion: 7, TransactionVersion: 2}
+	bz, err := json.Marshal(params)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(paramsPath, bz, 0o600))
+
+	c, err := client.LoadOfflineClient(metaPath, paramsPath)
+	require.NoError(t, err)
+	require.Equal(t, meta.Version, c.Metadata().Version)
+	require.Equal(t, len(meta.AsMetadataV14.Pallets), len(c.Metadata().AsMetadataV14.Pallets))
+	require.Equal(t, params.GenesisHash, c.GenesisHash())
+	require.Equal(t, params.SpecVersion, c.RuntimeVersion().SpecVersion)
+
+	// raw SCALE bytes are accepted as well
+	raw, err := codec.Encode(meta)
+	require.NoError(t, err)
+	rawPath := filepath.Join(dir, "metadata.scale")
+	require.NoError(t, os.WriteFile(rawPath, raw, 0o600))
+	decoded, err := client.ReadMetadataFile(rawPath)
+	require.NoError(t, err)
+	require.Equal(t, len(meta.AsMetadataV14.Pallets), len(decoded.AsMetadataV14.Pallets))
+}
@@ -1,6 +1,10 @@
 package client
 
-import "github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/transport"
+)
 
 type Option func(*Client)
 
@@ -15,3 +19,51 @@ func WithNetwork(network uint16) Option {
 		c.Network = network
 	}
 }
+
+// WithEndpoints adds fallback endpoints. When the connection drops the client
+// reconnects to the next endpoint in the list, wrapping around to the first.
+func WithEndpoints(endpoints ...string) Option {
+	return func(c *Client) {
+		c.endpoints = append(c.endpoints, endpoints...)
+	}
+}
+
+func WithBackoff(backoff Backoff) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+// WithRuntimeUpgradeHook registers a hook called whenever the client detects a
+// runtime upgrade and has switched to the new metadata.
+func WithRuntimeUpgradeHook(hook RuntimeUpgradeHook) Option {
+	return func(c *Client) {
+		c.upgradeHooks = append(c.upgradeHooks, hook)
+	}
+}
+
+// WithExpectedGenesis makes the client refuse nodes whose genesis hash differs,
+// both when connecting and when failing over to another endpoint.
+func WithExpectedGenesis(hash types.Hash) Option {
+	return func(c *Client) {
+		c.expectedGenesis = hash
+	}
+}
+
+// WithDialer replaces how the client connects to its endpoints. The default,
+// transport.Dial, picks websocket or HTTP from the endpoint's URL scheme.
+func WithDialer(dialer transport.Dialer) Option {
+	return func(c *Client) {
+		c.dialer = dialer
+	}
+}
+
+// WithCache keeps responses of calls made through CallAt at a block hash,
+// which storage and runtime queries use, evicting the least recently used
+// past maxEntries responses or maxBytes of JSON. A zero bound is no bound.
+// Queries of the best block are never cached.
+func WithCache(maxEntries, maxBytes int) Option {
+	return func(c *Client) {
+		c.cache = newCache(maxEntries, maxBytes)
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// Subscription is a node subscription the client keeps alive, resubscribing
+// after connection errors, until Unsubscribe is called or the client is
+// closed.
+type Subscription struct {
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Unsubscribe ends the subscription and waits for the handler to return. It
+// must not be called from the handler itself.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
+
+// Done is closed once Unsubscribe has been called
+func (s *Subscription) Done() <-chan struct{} {
+	return s.stop
+}
+
+// Subscribe subscribes to <namespace>_subscribe<name> with params and passes
+// every notification, sent as notification, to handle. The first
+// subscription is made before returning so that errors, such as a transport
+// without subscriptions, are reported right away. After that, dropped
+// subscriptions are renewed with the client's backoff and lost, if set, is
+// called each time. Notifications are handled one at a time.
+//
+// The gsrpc subscription wrappers are not used because they close their
+// channel while a notification may still be in flight.
+func Subscribe[T any](ctx context.Context, c *Client, namespace, name, notification string, params []any, handle func(T), lost func()) (*Subscription, error) {
+	subscribe := subscriber[T](c, namespace, name, notification, params)
+	sub, ch, err := subscribe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s_subscribe%s: %w", namespace, name, err)
+	}
+
+	s := &Subscription{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		followFrom(c, s.stop, subscribe, sub, ch, handle, lost)
+	}()
+	return s, nil
+}
+
+type subscribeFunc[T any] func(ctx context.Context) (*gethrpc.ClientSubscription, chan T, error)
+
+func subscriber[T any](c *Client, namespace, name, notification string, params []any) subscribeFunc[T] {
+	return func(ctx context.Context) (*gethrpc.ClientSubscription, chan T, error) {
+		ch := make(chan T)
+		sub, err := c.Api.Client.Subscribe(ctx, namespace, "subscribe"+name, "unsubscribe"+name, notification, ch, params...)
+		return sub, ch, err
+	}
+}
+
+// followFrom passes notifications to handle until stop or the client is
+// closed, starting with sub if it is set and subscribing again whenever the
+// subscription drops. Transports without subscriptions end it right away.
+func followFrom[T any](c *Client, stop <-chan struct{}, subscribe subscribeFunc[T], sub *gethrpc.ClientSubscription, ch chan T, handle func(T), lost func()) {
+	attempt := 0
+	for {
+		if sub == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+			var err error
+			sub, ch, err = subscribe(ctx)
+			cancel()
+			if errors.Is(err, gethrpc.ErrNotificationsUnsupported) {
+				return
+			}
+			if err != nil {
+				sub = nil
+				attempt++
+				select {
+				case <-time.After(c.backoff.delay(attempt)):
+					continue
+				case <-c.done:
+					return
+				case <-stop:
+					return
+				}
+			}
+			attempt = 0
+		}
+
+	recv:
+		for {
+			select {
+			case v := <-ch:
+				handle(v)
+			case <-sub.Err():
+				break recv
+			case <-c.done:
+				// the subscription ends with the connection; unsubscribing
+				// here could deadlock with gethrpc tearing it down
+				return
+			case <-stop:
+				sub.Unsubscribe()
+				return
+			}
+		}
+		sub.Unsubscribe()
+		sub = nil
+		if lost != nil {
+			lost()
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestHTTPTransport(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+
+	c, err := client.NewClient(node.HTTPURL())
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.Equal(t, node.HTTPURL(), c.Endpoint())
+	require.Equal(t, testutils.FakeGenesisHash, c.GenesisHash())
+
+	hash, err := c.Api.RPC.Chain.GetBlockHash(0)
+	require.NoError(t, err)
+	require.Equal(t, testutils.FakeGenesisHash, hash)
+}
+
+func TestInMemoryTransport(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient(client.WithBackoff(testBackoff))
+	require.NotNil(t, c.Metadata())
+
+	node.Kill()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		node.Restart()
+	}()
+
+	hash, err := c.Api.RPC.Chain.GetBlockHash(0)
+	require.NoError(t, err, "call should succeed once the node is back")
+	require.Equal(t, testutils.FakeGenesisHash, hash)
+}
@@ -0,0 +1,81 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestRuntimeUpgradeRefreshesMetadata(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+
+	upgrades := make(chan [2]types.RuntimeVersion, 1)
+	c, err := client.NewClient(node.URL(), client.WithRuntimeUpgradeHook(func(prev, next types.RuntimeVersion) {
+		upgrades <- [2]types.RuntimeVersion{prev, next}
+	}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	before := c.Metadata()
+	require.Equal(t, testutils.FakeRuntimeVersion.SpecVersion, c.RuntimeVersion().SpecVersion)
+
+	next := testutils.FakeRuntimeVersion
+	next.SpecVersion++
+	node.SetRuntimeVersion(next)
+
+	select {
+	case got := <-upgrades:
+		require.Equal(t, testutils.FakeRuntimeVersion.SpecVersion, got[0].SpecVersion)
+		require.Equal(t, next.SpecVersion, got[1].SpecVersion)
+	case <-time.After(5 * time.Second):
+		t.Fatal("runtime upgrade hook was not called")
+	}
+
+	require.Equal(t, next.SpecVersion, c.RuntimeVersion().SpecVersion)
+	require.NotSame(t, before, c.Metadata(), "metadata should have been refetched")
+	//lint:ignore SA1019 the deprecated field is under test
+	require.Same(t, before, c.Meta, "Meta keeps the metadata from connect time")
+}
+
+func TestRuntimeUpgradeWhileDisconnected(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+
+	upgraded := make(chan struct{}, 1)
+	c, err := client.NewClient(node.URL(), client.WithBackoff(testBackoff), client.WithRuntimeUpgradeHook(func(prev, next types.RuntimeVersion) {
+		upgraded <- struct{}{}
+	}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	node.Kill()
+	next := testutils.FakeRuntimeVersion
+	next.SpecVersion++
+	node.SetRuntimeVersion(next)
+	node.Restart()
+
+	select {
+	case <-upgraded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runtime upgrade hook was not called after reconnect")
+	}
+	require.Equal(t, next.SpecVersion, c.RuntimeVersion().SpecVersion)
+}
+
+func TestRuntimeVersionUnchanged(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+
+	c, err := client.NewClient(node.URL(), client.WithRuntimeUpgradeHook(func(prev, next types.RuntimeVersion) {
+		t.Errorf("unexpected upgrade from %d to %d", prev.SpecVersion, next.SpecVersion)
+	}))
+	require.NoError(t, err)
+	defer c.Close()
+
+	meta := c.Metadata()
+	node.SetRuntimeVersion(testutils.FakeRuntimeVersion)
+	time.Sleep(100 * time.Millisecond)
+	require.Same(t, meta, c.Metadata())
+}
@@ -14,15 +14,10 @@ import (
 	"github.com/subtrahend-labs/gobt/extrinsics"
 	"github.com/subtrahend-labs/gobt/sigtools"
 	"github.com/subtrahend-labs/gobt/storage"
-	"github.com/vedhavyas/go-subkey/v2"
-)
-
-const (
-	network uint16 = 42
 )
 
 var (
-	endpoint  string
+	network   client.Network
 	sender    signature.KeyringPair
 	recipient types.MultiAddress
 )
@@ -33,9 +28,24 @@ func init() {
 		log.Fatal("Error loading .env file")
 	}
 	seed := os.Getenv("SOURCE_SEED")
-	endpoint = os.Getenv("ENDPOINT")
 	destination := os.Getenv("DEST_ACCOUNT_ID")
-	sender, err = signature.KeyringPairFromSecret(seed, network)
+
+	// NETWORK picks the preset, and with it the SS58 prefix; ENDPOINT
+	// overrides its endpoints
+	name := os.Getenv("NETWORK")
+	if name == "" {
+		name = "test"
+	}
+	var ok bool
+	network, ok = client.NetworkByName(name)
+	if !ok {
+		log.Fatalf("Unknown network %q", name)
+	}
+	if endpoint := os.Getenv("ENDPOINT"); endpoint != "" {
+		network.Endpoints = []string{endpoint}
+	}
+
+	sender, err = signature.KeyringPairFromSecret(seed, network.SS58Prefix)
 	if err != nil {
 		log.Fatalf("Error creating sender: %s", err)
 	}
@@ -47,16 +57,18 @@ func init() {
 }
 
 func main() {
-	senderSS58 := subkey.SS58Encode(sender.PublicKey, network)
-	recipientSS58 := subkey.SS58Encode(recipient.AsID.ToBytes(), network)
-	fmt.Println("sender ss58: ", senderSS58)
-	fmt.Println("recipient ss58: ", recipientSS58)
-
-	c, err := client.NewClient(endpoint, client.WithKeyring(&sender))
+	c, err := client.NewNetworkClient(network, client.WithKeyring(&sender))
 	if err != nil {
 		log.Fatalf("Error creating client: %s", err)
 	}
 
+	senderID, err := types.NewAccountID(sender.PublicKey)
+	if err != nil {
+		log.Fatalf("Error reading sender account: %s", err)
+	}
+	fmt.Println("sender ss58: ", c.SS58(*senderID))
+	fmt.Println("recipient ss58: ", c.SS58(recipient.AsID))
+
 	recipientInfo, err := storage.GetAccountInfo(c, recipient.AsID.ToBytes(), nil)
 	if err != nil {
 		log.Fatalf("Error getting storage: %s", err)
@@ -82,7 +94,7 @@ func main() {
 	}
 	err = ext.Sign(
 		sender,
-		c.Meta,
+		c.Metadata(),
 		options...,
 	)
 	if err != nil {
@@ -8,13 +8,13 @@ import (
 )
 
 func main() {
-	c, err := client.NewClient("wss://test.finney.opentensor.ai:443")
+	c, err := client.NewNetworkClient(client.Testnet)
 	if err != nil {
 		log.Fatalf("Error creating client: %s", err)
 	}
 
-	info.LookupExtrinsicArgs(c.Meta, "SubtensorModule", "commit_crv3_weights")
-	// info.PrintModulesAndCalls(c.Meta, nil)
-	// info.PrintExtensions(c.Meta, nil)
-	// info.PrintExtensionDetails(c.Meta, nil, "SubtensorSignedExtension")
+	info.LookupExtrinsicArgs(c.Metadata(), "SubtensorModule", "commit_crv3_weights")
+	// info.PrintModulesAndCalls(c.Metadata(), nil)
+	// info.PrintExtensions(c.Metadata(), nil)
+	// info.PrintExtensionDetails(c.Metadata(), nil, "SubtensorSignedExtension")
 }
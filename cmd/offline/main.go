@@ -0,0 +1,59 @@
+// Exports what an offline client needs from a live node: the metadata and
+// the genesis hash, spec version and transaction version it belongs to.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+func main() {
+	networkName := flag.String("network", "test", "network preset to export from: finney, test, local or archive")
+	endpoint := flag.String("endpoint", "", "node to export from, overrides the preset endpoints")
+	out := flag.String("out", ".", "directory to write metadata.hex and params.json to")
+	flag.Parse()
+
+	network, ok := client.NetworkByName(*networkName)
+	if !ok {
+		log.Fatalf("Unknown network %q", *networkName)
+	}
+	if *endpoint != "" {
+		network.Endpoints = []string{*endpoint}
+	}
+
+	c, err := client.NewNetworkClient(network)
+	if err != nil {
+		log.Fatalf("Error creating client: %s", err)
+	}
+	defer c.Close()
+
+	meta := c.Metadata()
+	rv := c.RuntimeVersion()
+	params := client.OfflineParams{
+		GenesisHash:        c.GenesisHash(),
+		SpecVersion:        rv.SpecVersion,
+		TransactionVersion: rv.TransactionVersion,
+	}
+
+	metaPath := filepath.Join(*out, "metadata.hex")
+	if err := client.WriteMetadataFile(metaPath, meta); err != nil {
+		log.Fatalf("Error writing metadata: %s", err)
+	}
+
+	bz, err := json.MarshalIndent(params, "", "  ")
+	if err != nil {
+		log.Fatalf("Error encoding params: %s", err)
+	}
+	paramsPath := filepath.Join(*out, "params.json")
+	if err := os.WriteFile(paramsPath, bz, 0o600); err != nil {
+		log.Fatalf("Error writing params: %s", err)
+	}
+
+	fmt.Printf("Wrote %s and %s (spec version %d, transaction version %d)\n", metaPath, paramsPath, rv.SpecVersion, rv.TransactionVersion)
+}
@@ -5,7 +5,6 @@ import (
 	"log"
 	"os"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/joho/godotenv"
 	"github.com/subtrahend-labs/gobt/client"
 	"github.com/subtrahend-labs/gobt/runtime"
@@ -19,24 +18,22 @@ func main() {
 
 	endpoint := os.Getenv("ENDPOINT")
 
-	client, err := client.NewClient(endpoint)
+	c, err := client.NewClient(endpoint)
 	if err != nil {
 		log.Fatalf("Error creating client: %s", err)
 	}
 
-	blockHash, err := client.Api.RPC.Chain.GetBlockHashLatest()
-	if err != nil {
-		log.Fatalf("Error getting latest block hash: %s", err)
-	}
+	// both queries read the block that is latest when the first one runs
+	block := client.Latest()
 
 	netuid := 4
-	getNeurons(client, uint16(netuid), &blockHash)
-	getMetagraph(client, uint16(netuid), &blockHash)
+	getNeurons(c, uint16(netuid), block)
+	getMetagraph(c, uint16(netuid), block)
 }
 
-func getMetagraph(c *client.Client, netuid uint16, blockHash *types.Hash) {
+func getMetagraph(c *client.Client, netuid uint16, block *client.Block) {
 	fmt.Printf("\nTesting netuid %d:\n", netuid)
-	metagraph, err := runtime.GetMetagraph(c, uint16(netuid), blockHash)
+	metagraph, err := runtime.GetMetagraph(c, uint16(netuid), block)
 	if err != nil {
 		log.Printf("Error fetching metagraph for netuid %d: %s", netuid, err)
 	}
@@ -49,9 +46,9 @@ func getMetagraph(c *client.Client, netuid uint16, blockHash *types.Hash) {
 	fmt.Printf("%+v\n", metagraph)
 }
 
-func getNeurons(c *client.Client, netuid uint16, blockHash *types.Hash) {
+func getNeurons(c *client.Client, netuid uint16, block *client.Block) {
 	fmt.Printf("\nTesting netuid %d:\n", netuid)
-	neurons, err := runtime.GetNeurons(c, uint16(netuid), blockHash)
+	neurons, err := runtime.GetNeurons(c, uint16(netuid), block)
 	if err != nil {
 		log.Printf("Error fetching neurons for netuid %d: %s", netuid, err)
 	}
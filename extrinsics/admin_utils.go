@@ -4,12 +4,12 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 	"github.com/subtrahend-labs/gobt/client"
-	"github.com/subtrahend-labs/gobt/runtime"
+	"github.com/subtrahend-labs/gobt/typetools"
 )
 
 func SudoSetNetworkRateLimitCall(c *client.Client, rateLimit types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_network_rate_limit",
 		rateLimit,
 	)
@@ -30,7 +30,7 @@ func SudoSetNetworkRateLimitExt(c *client.Client, rateLimit types.U64) (*extrins
 
 func SudoToggleEvmPrecompileCall(c *client.Client, precompileId types.U8, enabled types.Bool) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_toggle_evm_precompile",
 		precompileId,
 		enabled,
@@ -50,9 +50,9 @@ func SudoToggleEvmPrecompileExt(c *client.Client, precompileId types.U8, enabled
 	return &ext, nil
 }
 
-func SudoSetSubnetMovingAlphaCall(c *client.Client, alpha runtime.I96F32) (types.Call, error) {
+func SudoSetSubnetMovingAlphaCall(c *client.Client, alpha typetools.I96F32) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_subnet_moving_alpha",
 		alpha,
 	)
@@ -62,7 +62,7 @@ func SudoSetSubnetMovingAlphaCall(c *client.Client, alpha runtime.I96F32) (types
 	return call, nil
 }
 
-func SudoSetSubnetMovingAlphaExt(c *client.Client, alpha runtime.I96F32) (*extrinsic.Extrinsic, error) {
+func SudoSetSubnetMovingAlphaExt(c *client.Client, alpha typetools.I96F32) (*extrinsic.Extrinsic, error) {
 	call, err := SudoSetSubnetMovingAlphaCall(c, alpha)
 	if err != nil {
 		return nil, err
@@ -73,7 +73,7 @@ func SudoSetSubnetMovingAlphaExt(c *client.Client, alpha runtime.I96F32) (*extri
 
 func SudoSetSubnetOwnerHotkeyCall(c *client.Client, netuid types.U16, hotkey types.AccountID) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_subnet_owner_hotkey",
 		netuid,
 		hotkey,
@@ -95,7 +95,7 @@ func SudoSetSubnetOwnerHotkeyExt(c *client.Client, netuid types.U16, hotkey type
 
 func SudoSetEmaPriceHalvingPeriodCall(c *client.Client, netuid types.U16, emaHalving types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_ema_price_halving_period",
 		netuid,
 		emaHalving,
@@ -117,7 +117,7 @@ func SudoSetEmaPriceHalvingPeriodExt(c *client.Client, netuid types.U16, emaHalv
 
 func SudoSetMaxDifficultyCall(c *client.Client, netuid uint16, max_difficulty types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_max_difficulty",
 		netuid,
 		max_difficulty,
@@ -139,7 +139,7 @@ func SudoSetMaxDifficultyExt(c *client.Client, netuid uint16, max_difficulty typ
 
 func SudoSetMinDifficultyCall(c *client.Client, netuid uint16, min_difficulty types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_min_difficulty",
 		netuid,
 		min_difficulty,
@@ -161,7 +161,7 @@ func SudoSetMinDifficultyExt(c *client.Client, netuid uint16, min_difficulty typ
 
 func SudoSetDifficultyCall(c *client.Client, netuid uint16, default_difficulty types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_difficulty",
 		netuid,
 		default_difficulty,
@@ -183,7 +183,7 @@ func SudoSetDifficultyExt(c *client.Client, netuid uint16, default_difficulty ty
 
 func SudoSetWeightsVersionKeyCall(c *client.Client, netuid uint16, weights_version_key types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_weights_version_key",
 		netuid,
 		weights_version_key,
@@ -205,7 +205,7 @@ func SudoSetWeightsVersionKeyExt(c *client.Client, netuid uint16, weights_versio
 
 func SudoSetTempoCall(c *client.Client, netuid uint16, tempo uint16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_tempo",
 		netuid,
 		tempo,
@@ -227,7 +227,7 @@ func SudoSetTempoExt(c *client.Client, netuid uint16, tempo uint16) (*extrinsic.
 
 func SudoSetDefaultTakeCall(c *client.Client, default_take types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_default_take",
 		default_take,
 	)
@@ -248,7 +248,7 @@ func SudoSetDefaultTakeExt(c *client.Client, default_take types.U16) (*extrinsic
 
 func SudoSetTxRateLimitCall(c *client.Client, tx_rate_limit types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_tx_rate_limit",
 		tx_rate_limit,
 	)
@@ -269,7 +269,7 @@ func SudoSetTxRateLimitExt(c *client.Client, tx_rate_limit types.U64) (*extrinsi
 
 func SudoSetServingRateLimitCall(c *client.Client, netuid types.U16, serving_rate_limit types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_serving_rate_limit",
 		netuid,
 		serving_rate_limit,
@@ -291,7 +291,7 @@ func SudoSetServingRateLimitExt(c *client.Client, netuid types.U16, serving_rate
 
 func SudoSetAdjustmentIntervalCall(c *client.Client, netuid types.U16, adjustment_interval types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_adjustment_interval",
 		netuid,
 		adjustment_interval,
@@ -313,7 +313,7 @@ func SudoSetAdjustmentIntervalExt(c *client.Client, netuid types.U16, adjustment
 
 func SudoSetAdjustmentAlphaCall(c *client.Client, netuid types.U16, adjustment_alpha types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_adjustment_alpha",
 		netuid,
 		adjustment_alpha,
@@ -335,7 +335,7 @@ func SudoSetAdjustmentAlphaExt(c *client.Client, netuid types.U16, adjustment_al
 
 func SudoSetMaxWeightLimitCall(c *client.Client, netuid types.U16, max_weight_limit types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_max_weight_limit",
 		netuid,
 		max_weight_limit,
@@ -357,7 +357,7 @@ func SudoSetMaxWeightLimitExt(c *client.Client, netuid types.U16, max_weight_lim
 
 func SudoSetImmunityPeriodCall(c *client.Client, netuid types.U16, immunity_period types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_immunity_period",
 		netuid,
 		immunity_period,
@@ -379,7 +379,7 @@ func SudoSetImmunityPeriodExt(c *client.Client, netuid types.U16, immunity_perio
 
 func SudoSetMinAllowedWeightsCall(c *client.Client, netuid types.U16, min_allowed_weights types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_min_allowed_weights",
 		netuid,
 		min_allowed_weights,
@@ -401,7 +401,7 @@ func SudoSetMinAllowedWeightsExt(c *client.Client, netuid types.U16, min_allowed
 
 func SudoSetMaxAllowedUidsCall(c *client.Client, netuid types.U16, max_allowed_uids types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_max_allowed_uids",
 		netuid,
 		max_allowed_uids,
@@ -423,7 +423,7 @@ func SudoSetMaxAllowedUidsExt(c *client.Client, netuid types.U16, max_allowed_ui
 
 func SudoSetKappaCall(c *client.Client, netuid types.U16, kappa types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_kappa",
 		netuid,
 		kappa,
@@ -445,7 +445,7 @@ func SudoSetKappaExt(c *client.Client, netuid types.U16, kappa types.U16) (*extr
 
 func SudoSetRhoCall(c *client.Client, netuid types.U16, rho types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_rho",
 		netuid,
 		rho,
@@ -467,7 +467,7 @@ func SudoSetRhoExt(c *client.Client, netuid types.U16, rho types.U16) (*extrinsi
 
 func SudoSetActivityCutoffCall(c *client.Client, netuid types.U16, activity_cutoff types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_activity_cutoff",
 		netuid,
 		activity_cutoff,
@@ -489,7 +489,7 @@ func SudoSetActivityCutoffExt(c *client.Client, netuid types.U16, activity_cutof
 
 func SudoSetNetworkRegistrationAllowedCall(c *client.Client, netuid types.U16, registration_allowed bool) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_network_registration_allowed",
 		netuid,
 		registration_allowed,
@@ -511,7 +511,7 @@ func SudoSetNetworkRegistrationAllowedExt(c *client.Client, netuid types.U16, re
 
 func SudoSetNetworkPowRegistrationAllowedCall(c *client.Client, netuid types.U16, registration_allowed bool) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_network_pow_registration_allowed",
 		netuid,
 		registration_allowed,
@@ -533,7 +533,7 @@ func SudoSetNetworkPowRegistrationAllowedExt(c *client.Client, netuid types.U16,
 
 func SudoSetTargetRegistrationsPerIntervalCall(c *client.Client, netuid types.U16, target_registrations_per_interval types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_target_registrations_per_interval",
 		netuid,
 		target_registrations_per_interval,
@@ -555,7 +555,7 @@ func SudoSetTargetRegistrationsPerIntervalExt(c *client.Client, netuid types.U16
 
 func SudoSetMinBurnCall(c *client.Client, netuid types.U16, min_burn types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_min_burn",
 		netuid,
 		min_burn,
@@ -577,7 +577,7 @@ func SudoSetMinBurnExt(c *client.Client, netuid types.U16, min_burn types.U64) (
 
 func SudoSetMaxBurnCall(c *client.Client, netuid types.U16, max_burn types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_max_burn",
 		netuid,
 		max_burn,
@@ -599,7 +599,7 @@ func SudoSetMaxBurnExt(c *client.Client, netuid types.U16, max_burn types.U64) (
 
 func SudoSetMaxAllowedValidatorsCall(c *client.Client, netuid types.U16, max_allowed_validators types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_max_allowed_validators",
 		netuid,
 		max_allowed_validators,
@@ -621,7 +621,7 @@ func SudoSetMaxAllowedValidatorsExt(c *client.Client, netuid types.U16, max_allo
 
 func SudoSetBondsMovingAverageCall(c *client.Client, netuid types.U16, bonds_moving_average types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_bonds_moving_average",
 		netuid,
 		bonds_moving_average,
@@ -643,7 +643,7 @@ func SudoSetBondsMovingAverageExt(c *client.Client, netuid types.U16, bonds_movi
 
 func SudoSetBondsPenaltyCall(c *client.Client, netuid types.U16, bonds_penalty types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_bonds_penalty",
 		netuid,
 		bonds_penalty,
@@ -665,7 +665,7 @@ func SudoSetBondsPenaltyExt(c *client.Client, netuid types.U16, bonds_penalty ty
 
 func SudoSetMaxRegistrationsPerBlockCall(c *client.Client, netuid types.U16, max_registrations_per_block types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_max_registrations_per_block",
 		netuid,
 		max_registrations_per_block,
@@ -687,7 +687,7 @@ func SudoSetMaxRegistrationsPerBlockExt(c *client.Client, netuid types.U16, max_
 
 func SudoSetSubnetOwnerCutCall(c *client.Client, subnet_owner_cut types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"AdminUtils.sudo_set_subnet_owner_cut",
 		subnet_owner_cut,
 	)
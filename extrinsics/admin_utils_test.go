@@ -44,7 +44,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		t.Parallel()
 		env := setup(t)
 
-		alpha := runtime.I96F32{
+		alpha := typetools.I96F32{
 			Bits: types.NewU128(*big.NewInt(1000000000)),
 		}
 
@@ -59,7 +59,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 
 		meta, _ := env.Client.Api.RPC.State.GetMetadataLatest()
 		storageKey, _ := types.CreateStorageKey(meta, "SubtensorModule", "SubnetMovingAlpha")
-		var result runtime.I96F32
+		var result typetools.I96F32
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &result)
 
 		require.Equal(t, alpha.Bits, result.Bits)
@@ -109,7 +109,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MaxDelegateTake")
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MaxDelegateTake")
 
 		var newDefaultTake types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newDefaultTake)
@@ -126,7 +126,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "TxRateLimit")
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "TxRateLimit")
 		var newTxRateLimit types.U64
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newTxRateLimit)
 
@@ -142,7 +142,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "ServingRateLimit", typetools.Uint16ToBytes(uint16(4)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "ServingRateLimit", typetools.Uint16ToBytes(uint16(4)))
 		var newServingRateLimit types.U64
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newServingRateLimit)
 
@@ -268,7 +268,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "AdjustmentInterval", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "AdjustmentInterval", typetools.Uint16ToBytes(uint16(0)))
 		var newAdjustmentInterval types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newAdjustmentInterval)
 
@@ -284,7 +284,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "AdjustmentAlpha", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "AdjustmentAlpha", typetools.Uint16ToBytes(uint16(0)))
 		var newAdjustmentAlpha types.U64
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newAdjustmentAlpha)
 
@@ -300,7 +300,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MaxWeightsLimit", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MaxWeightsLimit", typetools.Uint16ToBytes(uint16(0)))
 		var newMaxWeightLimit types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newMaxWeightLimit)
 
@@ -316,7 +316,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "ImmunityPeriod", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "ImmunityPeriod", typetools.Uint16ToBytes(uint16(0)))
 		var newImmunityPeriod types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newImmunityPeriod)
 
@@ -332,7 +332,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MinAllowedWeights", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MinAllowedWeights", typetools.Uint16ToBytes(uint16(0)))
 		var newMinAllowedWeights types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newMinAllowedWeights)
 
@@ -347,7 +347,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MaxAllowedUids", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MaxAllowedUids", typetools.Uint16ToBytes(uint16(0)))
 		var newMaxAllowedUids types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newMaxAllowedUids)
 
@@ -363,7 +363,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "Kappa", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "Kappa", typetools.Uint16ToBytes(uint16(0)))
 		var newKappa types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newKappa)
 
@@ -379,7 +379,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "Rho", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "Rho", typetools.Uint16ToBytes(uint16(0)))
 		var newRho types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newRho)
 
@@ -396,7 +396,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "ActivityCutoff", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "ActivityCutoff", typetools.Uint16ToBytes(uint16(0)))
 		var newActivityCutoff types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newActivityCutoff)
 
@@ -412,7 +412,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "NetworkRegistrationAllowed", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "NetworkRegistrationAllowed", typetools.Uint16ToBytes(uint16(0)))
 		var newNetworkRegistrationAllowed bool
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newNetworkRegistrationAllowed)
 
@@ -428,7 +428,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "NetworkPowRegistrationAllowed", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "NetworkPowRegistrationAllowed", typetools.Uint16ToBytes(uint16(0)))
 		var newNetworkPowRegistrationAllowed bool
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newNetworkPowRegistrationAllowed)
 
@@ -444,7 +444,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "TargetRegistrationsPerInterval", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "TargetRegistrationsPerInterval", typetools.Uint16ToBytes(uint16(0)))
 		var newTargetRegistrationsPerInterval types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newTargetRegistrationsPerInterval)
 
@@ -460,7 +460,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MinBurn", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MinBurn", typetools.Uint16ToBytes(uint16(0)))
 		var newMinBurn types.U64
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newMinBurn)
 
@@ -476,7 +476,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MaxBurn", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MaxBurn", typetools.Uint16ToBytes(uint16(0)))
 		var newMaxBurn types.U64
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newMaxBurn)
 
@@ -492,7 +492,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "Difficulty", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "Difficulty", typetools.Uint16ToBytes(uint16(0)))
 		var newDifficulty types.U64
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newDifficulty)
 
@@ -516,7 +516,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ = NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MaxAllowedValidators", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MaxAllowedValidators", typetools.Uint16ToBytes(uint16(0)))
 		var newMaxAllowedValidators types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newMaxAllowedValidators)
 
@@ -532,7 +532,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "BondsMovingAverage", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "BondsMovingAverage", typetools.Uint16ToBytes(uint16(0)))
 		var newBondsMovingAverage types.U64
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newBondsMovingAverage)
 
@@ -548,7 +548,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "BondsPenalty", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "BondsPenalty", typetools.Uint16ToBytes(uint16(0)))
 		var newBondsPenalty types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newBondsPenalty)
 
@@ -564,7 +564,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "MaxRegistrationsPerBlock", typetools.Uint16ToBytes(uint16(0)))
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "MaxRegistrationsPerBlock", typetools.Uint16ToBytes(uint16(0)))
 		var newMaxRegistrationsPerBlock types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newMaxRegistrationsPerBlock)
 
@@ -580,7 +580,7 @@ func TestAdminUtilsModuleExtrinsics(t *testing.T) {
 		ext, _ := NewSudoExt(env.Client, &sudoCall)
 		testutils.SignAndSubmit(t, env.Client, ext, env.Alice.Coldkey.Keypair, uint32(env.Alice.Coldkey.AccInfo.Nonce))
 
-		storageKey, _ := types.CreateStorageKey(env.Client.Meta, "SubtensorModule", "SubnetOwnerCut")
+		storageKey, _ := types.CreateStorageKey(env.Client.Metadata(), "SubtensorModule", "SubnetOwnerCut")
 		var newSubnetOwnerCut types.U16
 		env.Client.Api.RPC.State.GetStorageLatest(storageKey, &newSubnetOwnerCut)
 		require.Equal(t, subnetOwnerCut, newSubnetOwnerCut, "Subnet owner cut was not updated correctly")
@@ -7,7 +7,7 @@ import (
 )
 
 func TransferAllowDeathCall(c *client.Client, recipient types.MultiAddress, amount types.UCompact) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.transfer_allow_death", recipient, amount)
+	call, err := types.NewCall(c.Metadata(), "Balances.transfer_allow_death", recipient, amount)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -24,7 +24,7 @@ func TransferAllowDeathExt(c *client.Client, recipient types.MultiAddress, amoun
 }
 
 func TransferKeepAliveCall(c *client.Client, recipient types.MultiAddress, amount types.UCompact) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.transfer_keep_alive", recipient, amount)
+	call, err := types.NewCall(c.Metadata(), "Balances.transfer_keep_alive", recipient, amount)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -42,7 +42,7 @@ func TransferKeepAliveExt(c *client.Client, recipient types.MultiAddress, amount
 
 // TODO: should include or only through sudo
 func ForceTransferCall(c *client.Client, source types.MultiAddress, recipient types.MultiAddress, amount types.UCompact) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.force_transfer", source, recipient, amount)
+	call, err := types.NewCall(c.Metadata(), "Balances.force_transfer", source, recipient, amount)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -60,7 +60,7 @@ func ForceTransferExt(c *client.Client, source types.MultiAddress, recipient typ
 }
 
 func TransferAllCall(c *client.Client, recipient types.MultiAddress, keepAlive bool) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.transfer_all", recipient, types.NewBool(keepAlive))
+	call, err := types.NewCall(c.Metadata(), "Balances.transfer_all", recipient, types.NewBool(keepAlive))
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -77,7 +77,7 @@ func TransferAllExt(c *client.Client, recipient types.MultiAddress, keepAlive bo
 }
 
 func ForceUnreserveCall(c *client.Client, who types.AccountID, currencyId types.UCompact) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.force_unreserve", who, currencyId)
+	call, err := types.NewCall(c.Metadata(), "Balances.force_unreserve", who, currencyId)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -94,7 +94,7 @@ func ForceUnreserveExt(c *client.Client, who types.AccountID, currencyId types.U
 }
 
 func UpgradeAccountsCall(c *client.Client, newAccount types.AccountID, numSlashingSpans types.U32) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.upgrade_accounts", newAccount, numSlashingSpans)
+	call, err := types.NewCall(c.Metadata(), "Balances.upgrade_accounts", newAccount, numSlashingSpans)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -111,7 +111,7 @@ func UpgradeAccountsExt(c *client.Client, newAccount types.AccountID, numSlashin
 }
 
 func ForceSetBalanceCall(c *client.Client, who types.MultiAddress, newFree types.UCompact, newReserved types.UCompact) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.force_set_balance", who, newFree, newReserved)
+	call, err := types.NewCall(c.Metadata(), "Balances.force_set_balance", who, newFree, newReserved)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -128,7 +128,7 @@ func ForceSetBalanceExt(c *client.Client, who types.MultiAddress, newFree types.
 }
 
 func ForceSetTotalIssuanceCall(c *client.Client, newTotal types.U128) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.force_set_total_issuance", newTotal)
+	call, err := types.NewCall(c.Metadata(), "Balances.force_set_total_issuance", newTotal)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -145,7 +145,7 @@ func ForceSetTotalIssuanceExt(c *client.Client, newTotal types.U128) (*extrinsic
 }
 
 func BurnCall(c *client.Client, currencyId types.UCompact, amount types.U128) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Balances.burn", currencyId, amount)
+	call, err := types.NewCall(c.Metadata(), "Balances.burn", currencyId, amount)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -70,7 +70,7 @@ type SubnetIdentityV2 struct {
 }
 
 func AddStakeCall(c *client.Client, hotkey types.AccountID, netuid types.U16, amount_staked types.U64) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "SubtensorModule.add_stake", hotkey, netuid, amount_staked)
+	call, err := types.NewCall(c.Metadata(), "SubtensorModule.add_stake", hotkey, netuid, amount_staked)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -87,7 +87,7 @@ func AddStakeExt(c *client.Client, hotkey types.AccountID, netuid types.U16, amo
 }
 
 func AddStakeLimitCall(c *client.Client, hotkey types.AccountID, netuid types.U16, amount_staked types.U64, limit_price types.U64, allow_partial types.Bool) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "SubtensorModule.add_stake_limit", hotkey, netuid, amount_staked, limit_price, allow_partial)
+	call, err := types.NewCall(c.Metadata(), "SubtensorModule.add_stake_limit", hotkey, netuid, amount_staked, limit_price, allow_partial)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -104,7 +104,7 @@ func AddStakeLimitExt(c *client.Client, hotkey types.AccountID, netuid types.U16
 }
 
 func RemoveStakeLimitCall(c *client.Client, hotkey types.AccountID, netuid types.U16, amount_unstaked types.U64, limit_price types.U64, allow_partial types.Bool) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "SubtensorModule.remove_stake_limit", hotkey, netuid, amount_unstaked, limit_price, allow_partial)
+	call, err := types.NewCall(c.Metadata(), "SubtensorModule.remove_stake_limit", hotkey, netuid, amount_unstaked, limit_price, allow_partial)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -121,7 +121,7 @@ func RemoveStakeLimitExt(c *client.Client, hotkey types.AccountID, netuid types.
 }
 
 func SetWeightsCall(c *client.Client, netuid types.U16, uids []types.U16, weights []types.U16, versionKey types.U64) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "SubtensorModule.set_weights", netuid, uids, weights, versionKey)
+	call, err := types.NewCall(c.Metadata(), "SubtensorModule.set_weights", netuid, uids, weights, versionKey)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -138,7 +138,7 @@ func SetWeightsExt(c *client.Client, netuid types.U16, uids []types.U16, weights
 }
 
 func RootRegisterCall(c *client.Client, hotkey types.AccountID) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "SubtensorModule.root_register", hotkey)
+	call, err := types.NewCall(c.Metadata(), "SubtensorModule.root_register", hotkey)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -156,7 +156,7 @@ func RootRegisterExt(c *client.Client, hotkey types.AccountID) (*extrinsic.Extri
 
 func RegisterCall(c *client.Client, netuid types.U16, blockNumber types.U64, nonce types.U64, work types.Bytes, hotkey types.AccountID, coldkey types.AccountID) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"SubtensorModule.register",
 		netuid,
 		blockNumber,
@@ -182,7 +182,7 @@ func RegisterExt(c *client.Client, netuid types.U16, blockNumber types.U64, nonc
 
 func BurnedRegisterCall(c *client.Client, hotkey types.AccountID, netuid types.U16) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"SubtensorModule.burned_register",
 		netuid,
 		hotkey,
@@ -204,7 +204,7 @@ func BurnedRegisterExt(c *client.Client, hotkey types.AccountID, netuid types.U1
 
 func RegisterNetworkCall(c *client.Client, hotkey types.AccountID) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"SubtensorModule.register_network",
 		hotkey,
 	)
@@ -228,7 +228,7 @@ func ServeAxonCall(c *client.Client, netuid types.U16, version types.U32, ip typ
 	placeholder2 types.U8) (types.Call, error) {
 
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"SubtensorModule.serve_axon",
 		netuid,
 		version,
@@ -267,7 +267,7 @@ func ServeAxonTLSCall(c *client.Client, netuid types.U16, version types.U32, ip
 	placeholder2 types.U8, certificate types.Bytes) (types.Call, error) {
 
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"SubtensorModule.serve_axon_tls",
 		netuid,
 		version,
@@ -304,7 +304,7 @@ func ServeAxonTLSExt(c *client.Client, netuid types.U16, version types.U32, ip t
 
 func CommitCRV3WeightsCall(c *client.Client, netuid types.U16, commit types.Bytes, revealRound types.U64) (types.Call, error) {
 	call, err := types.NewCall(
-		c.Meta,
+		c.Metadata(),
 		"SubtensorModule.commit_crv3_weights",
 		netuid,
 		commit,
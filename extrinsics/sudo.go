@@ -7,7 +7,7 @@ import (
 )
 
 func NewSudoCall(c *client.Client, ext *types.Call) (types.Call, error) {
-	call, err := types.NewCall(c.Meta, "Sudo.sudo", ext)
+	call, err := types.NewCall(c.Metadata(), "Sudo.sudo", ext)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -15,7 +15,7 @@ func NewSudoCall(c *client.Client, ext *types.Call) (types.Call, error) {
 }
 
 func NewSudoExt(c *client.Client, ext *types.Call) (*extrinsic.Extrinsic, error) {
-	call, err := types.NewCall(c.Meta, "Sudo.sudo", ext)
+	call, err := types.NewCall(c.Metadata(), "Sudo.sudo", ext)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ func NewSudoExt(c *client.Client, ext *types.Call) (*extrinsic.Extrinsic, error)
 }
 
 func NewSudoUncheckedWeightCall(c *client.Client, call *types.Call, weight types.Weight) (types.Call, error) {
-	sudoCall, err := types.NewCall(c.Meta, "Sudo.sudo_unchecked_weight", call, weight)
+	sudoCall, err := types.NewCall(c.Metadata(), "Sudo.sudo_unchecked_weight", call, weight)
 	if err != nil {
 		return types.Call{}, err
 	}
@@ -33,7 +33,7 @@ func NewSudoUncheckedWeightCall(c *client.Client, call *types.Call, weight types
 }
 
 func NewSudoUncheckedWeightExt(c *client.Client, call *types.Call, weight types.Weight) (*extrinsic.Extrinsic, error) {
-	sudoCall, err := types.NewCall(c.Meta, "Sudo.sudo_unchecked_weight", call, weight)
+	sudoCall, err := types.NewCall(c.Metadata(), "Sudo.sudo_unchecked_weight", call, weight)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,37 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+// callInfo calls one of the runtime info RPCs, which answer with SCALE
+// encoded bytes, and decodes the answer into res.
+func callInfo(ctx context.Context, c *client.Client, res any, method string, block *client.Block, args ...any) error {
+	blockHash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return err
+	}
+
+	var encodedResponse []byte
+	if err := c.CallAt(ctx, &encodedResponse, method, blockHash, args...); err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	if err := codec.Decode(encodedResponse, res); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", method, err)
+	}
+	return nil
+}
+
+// vecU8 passes bytes as the JSON array a Vec<u8> parameter expects, where a
+// []byte would be sent as base64.
+func vecU8(b []byte) []int {
+	v := make([]int, len(b))
+	for i, x := range b {
+		v[i] = int(x)
+	}
+	return v
+}
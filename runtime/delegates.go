@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+type DelegateInfo struct {
+	Delegate   types.AccountID
+	Take       types.UCompact
+	Nominators []Nominator
+	Owner      types.AccountID
+	// Netuids the delegate is registered on
+	Registrations []types.UCompact
+	// Netuids the delegate has a validator permit on
+	ValidatorPermits []types.UCompact
+	// Daily return per 1000 TAO staked, after the take
+	ReturnPer1000    types.UCompact
+	TotalDailyReturn types.UCompact
+}
+
+// Nominator is a coldkey staking to a delegate
+type Nominator struct {
+	Coldkey types.AccountID
+	Stakes  []NominatorStake
+}
+
+type NominatorStake struct {
+	Netuid types.UCompact
+	Stake  types.UCompact
+}
+
+// Delegated is a delegate a coldkey has staked to, with its stake on one
+// subnet
+type Delegated struct {
+	Delegate DelegateInfo
+	Netuid   types.UCompact
+	Stake    types.UCompact
+}
+
+func GetDelegates(c *client.Client, block *client.Block) ([]DelegateInfo, error) {
+	return GetDelegatesContext(context.Background(), c, block)
+}
+
+func GetDelegatesContext(ctx context.Context, c *client.Client, block *client.Block) ([]DelegateInfo, error) {
+	var delegates []DelegateInfo
+	if err := callInfo(ctx, c, &delegates, "delegateInfo_getDelegates", block); err != nil {
+		return nil, err
+	}
+	return delegates, nil
+}
+
+func GetDelegate(c *client.Client, hotkey types.AccountID, block *client.Block) (*DelegateInfo, error) {
+	return GetDelegateContext(context.Background(), c, hotkey, block)
+}
+
+func GetDelegateContext(ctx context.Context, c *client.Client, hotkey types.AccountID, block *client.Block) (*DelegateInfo, error) {
+	var delegate types.Option[DelegateInfo]
+	if err := callInfo(ctx, c, &delegate, "delegateInfo_getDelegate", block, vecU8(hotkey.ToBytes())); err != nil {
+		return nil, err
+	}
+	ok, d := delegate.Unwrap()
+	if ok {
+		return &d, nil
+	}
+	return nil, errors.New("no delegate found")
+}
+
+// GetDelegated lists the delegates a coldkey has staked to, once per subnet
+// it has stake on.
+func GetDelegated(c *client.Client, coldkey types.AccountID, block *client.Block) ([]Delegated, error) {
+	return GetDelegatedContext(context.Background(), c, coldkey, block)
+}
+
+func GetDelegatedContext(ctx context.Context, c *client.Client, coldkey types.AccountID, block *client.Block) ([]Delegated, error) {
+	var delegated []Delegated
+	if err := callInfo(ctx, c, &delegated, "delegateInfo_getDelegated", block, vecU8(coldkey.ToBytes())); err != nil {
+		return nil, err
+	}
+	return delegated, nil
+}
@@ -0,0 +1,87 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/runtime"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func testDelegate(t *testing.T) runtime.DelegateInfo {
+	hotkey, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+	return runtime.DelegateInfo{
+		Delegate: *hotkey,
+		Take:     types.NewUCompactFromUInt(11796),
+		Nominators: []runtime.Nominator{{
+			Coldkey: types.AccountID{1},
+			Stakes: []runtime.NominatorStake{
+				{Netuid: types.NewUCompactFromUInt(0), Stake: types.NewUCompactFromUInt(1e9)},
+				{Netuid: types.NewUCompactFromUInt(3), Stake: types.NewUCompactFromUInt(5e9)},
+			},
+		}},
+		Owner:            types.AccountID{2},
+		Registrations:    []types.UCompact{types.NewUCompactFromUInt(0), types.NewUCompactFromUInt(3)},
+		ValidatorPermits: []types.UCompact{types.NewUCompactFromUInt(3)},
+		ReturnPer1000:    types.NewUCompactFromUInt(420),
+		TotalDailyReturn: types.NewUCompactFromUInt(7000),
+	}
+}
+
+func TestGetDelegates(t *testing.T) {
+	want := []runtime.DelegateInfo{testDelegate(t)}
+	enc, err := codec.Encode(want)
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("delegateInfo_getDelegates", enc)
+	c := node.NewClient()
+
+	delegates, err := runtime.GetDelegates(c, nil)
+	require.NoError(t, err)
+	require.Equal(t, want, delegates)
+}
+
+func TestGetDelegated(t *testing.T) {
+	want := []runtime.Delegated{{
+		Delegate: testDelegate(t),
+		Netuid:   types.NewUCompactFromUInt(3),
+		Stake:    types.NewUCompactFromUInt(5e9),
+	}}
+	enc, err := codec.Encode(want)
+	require.NoError(t, err)
+
+	coldkey := types.AccountID{1}
+	node := testutils.NewFakeNode(t)
+	node.Handle("delegateInfo_getDelegated", func(params []json.RawMessage) (any, error) {
+		// the account goes as a Vec<u8>, a JSON array of bytes
+		var numbers []int
+		if err := json.Unmarshal(params[0], &numbers); err != nil || len(numbers) != 32 || numbers[0] != 1 {
+			return nil, fmt.Errorf("unexpected account param %s", params[0])
+		}
+		return enc, nil
+	})
+	c := node.NewClient()
+
+	delegated, err := runtime.GetDelegated(c, coldkey, nil)
+	require.NoError(t, err)
+	require.Equal(t, want, delegated)
+}
+
+func TestGetDelegateMissing(t *testing.T) {
+	enc, err := codec.Encode(types.NewEmptyOption[runtime.DelegateInfo]())
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("delegateInfo_getDelegate", enc)
+	c := node.NewClient()
+
+	_, err = runtime.GetDelegate(c, types.AccountID{1}, nil)
+	require.Error(t, err)
+}
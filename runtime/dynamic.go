@@ -0,0 +1,155 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+// DynamicInfo is the state of a subnet's alpha pool. Amounts are in rao, of
+// TAO or of the subnet's alpha.
+type DynamicInfo struct {
+	Netuid               types.UCompact                 // Compact<u16>
+	OwnerHotkey          types.AccountID                // AccountId
+	OwnerColdkey         types.AccountID                // AccountId
+	SubnetName           []types.UCompact               // Vec<Compact<u8>>
+	TokenSymbol          []types.UCompact               // Vec<Compact<u8>>
+	Tempo                types.UCompact                 // Compact<u16>
+	LastStep             types.UCompact                 // Compact<u64>
+	BlocksSinceLastStep  types.UCompact                 // Compact<u64>
+	Emission             types.UCompact                 // Compact<u64>
+	AlphaIn              types.UCompact                 // Compact<u64>
+	AlphaOut             types.UCompact                 // Compact<u64>
+	TaoIn                types.UCompact                 // Compact<u64>
+	AlphaOutEmission     types.UCompact                 // Compact<u64>
+	AlphaInEmission      types.UCompact                 // Compact<u64>
+	TaoInEmission        types.UCompact                 // Compact<u64>
+	PendingAlphaEmission types.UCompact                 // Compact<u64>
+	PendingRootEmission  types.UCompact                 // Compact<u64>
+	SubnetVolume         types.UCompact                 // Compact<u128>
+	NetworkRegisteredAt  types.UCompact                 // Compact<u64>
+	SubnetIdentity       types.Option[SubnetIdentityV2] // Option<SubnetIdentityV2>
+	MovingPrice          I96F32                         // fixed-point
+}
+
+func GetDynamicInfo(c *client.Client, netuid uint16, block *client.Block) (*DynamicInfo, error) {
+	return GetDynamicInfoContext(context.Background(), c, netuid, block)
+}
+
+func GetDynamicInfoContext(ctx context.Context, c *client.Client, netuid uint16, block *client.Block) (*DynamicInfo, error) {
+	var info types.Option[DynamicInfo]
+	if err := callInfo(ctx, c, &info, "subnetInfo_getDynamicInfo", block, netuid); err != nil {
+		return nil, err
+	}
+	ok, d := info.Unwrap()
+	if ok {
+		return &d, nil
+	}
+	return nil, fmt.Errorf("no subnet found for netuid %d", netuid)
+}
+
+// GetAllDynamicInfo returns the pools of every existing subnet, in netuid
+// order
+func GetAllDynamicInfo(c *client.Client, block *client.Block) ([]DynamicInfo, error) {
+	return GetAllDynamicInfoContext(context.Background(), c, block)
+}
+
+func GetAllDynamicInfoContext(ctx context.Context, c *client.Client, block *client.Block) ([]DynamicInfo, error) {
+	var infos []types.Option[DynamicInfo]
+	if err := callInfo(ctx, c, &infos, "subnetInfo_getAllDynamicInfo", block); err != nil {
+		return nil, err
+	}
+	return existing(infos), nil
+}
+
+// Name of the subnet
+func (d DynamicInfo) Name() string {
+	return compactString(d.SubnetName)
+}
+
+// Symbol of the subnet's alpha token
+func (d DynamicInfo) Symbol() string {
+	return compactString(d.TokenSymbol)
+}
+
+// Price is the spot price of alpha in TAO, the ratio of the pool's reserves.
+// The root subnet has no pool and trades at 1.
+func (d DynamicInfo) Price() float64 {
+	if d.isRoot() {
+		return 1
+	}
+	alphaIn := bigInt(d.AlphaIn)
+	if alphaIn.Sign() == 0 {
+		return 0
+	}
+	p, _ := new(big.Rat).SetFrac(bigInt(d.TaoIn), alphaIn).Float64()
+	return p
+}
+
+// StakeSlippage returns the alpha received for staking tao and the slippage,
+// the alpha lost to the pool compared to buying at the spot price. The
+// slippage as a fraction of the trade is slippage / (alpha + slippage).
+func (d DynamicInfo) StakeSlippage(tao uint64) (alpha, slippage uint64) {
+	if d.isRoot() {
+		return tao, 0
+	}
+	return swap(bigInt(d.TaoIn), bigInt(d.AlphaIn), tao)
+}
+
+// UnstakeSlippage returns the TAO received for unstaking alpha and the
+// slippage, the TAO lost to the pool compared to selling at the spot price.
+func (d DynamicInfo) UnstakeSlippage(alpha uint64) (tao, slippage uint64) {
+	if d.isRoot() {
+		return alpha, 0
+	}
+	return swap(bigInt(d.AlphaIn), bigInt(d.TaoIn), alpha)
+}
+
+func (d DynamicInfo) isRoot() bool {
+	return bigInt(d.Netuid).Sign() == 0
+}
+
+// swap trades amount into a constant product pool holding in and out
+// reserves, returning what comes out and how much less that is than at the
+// spot price.
+func swap(in, out *big.Int, amount uint64) (received, slippage uint64) {
+	if in.Sign() == 0 {
+		return 0, 0
+	}
+	a := new(big.Int).SetUint64(amount)
+
+	// out - in*out/(in+a) = out*a/(in+a)
+	got := new(big.Int).Mul(out, a)
+	got.Quo(got, new(big.Int).Add(in, a))
+
+	ideal := new(big.Int).Mul(out, a)
+	ideal.Quo(ideal, in)
+
+	return saturate(got), saturate(ideal.Sub(ideal, got))
+}
+
+// saturate caps n at math.MaxUint64; a thin pool can price a trade at more
+// than a uint64 holds
+func saturate(n *big.Int) uint64 {
+	if !n.IsUint64() {
+		return math.MaxUint64
+	}
+	return n.Uint64()
+}
+
+func bigInt(u types.UCompact) *big.Int {
+	v := big.Int(u)
+	return &v
+}
+
+func compactString(bz []types.UCompact) string {
+	b := make([]byte, len(bz))
+	for i, c := range bz {
+		b[i] = byte(bigInt(c).Uint64())
+	}
+	return string(b)
+}
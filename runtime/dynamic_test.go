@@ -0,0 +1,112 @@
+package runtime_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/runtime"
+	"github.com/subtrahend-labs/gobt/testutils"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+func compactBytes(s string) []types.UCompact {
+	res := make([]types.UCompact, len(s))
+	for i, b := range []byte(s) {
+		res[i] = types.NewUCompactFromUInt(uint64(b))
+	}
+	return res
+}
+
+// pool builds the dynamic info of a subnet holding taoIn TAO and alphaIn
+// alpha, in rao
+func pool(netuid, taoIn, alphaIn uint64) runtime.DynamicInfo {
+	return runtime.DynamicInfo{
+		Netuid:      types.NewUCompactFromUInt(netuid),
+		SubnetName:  compactBytes("apex"),
+		TokenSymbol: compactBytes("α"),
+		TaoIn:       types.NewUCompactFromUInt(taoIn),
+		AlphaIn:     types.NewUCompactFromUInt(alphaIn),
+		// 0.25 TAO per alpha
+		MovingPrice: typetools.I96F32{Bits: types.NewU128(*big.NewInt(1 << 30))},
+	}
+}
+
+func TestGetAllDynamicInfo(t *testing.T) {
+	enc, err := codec.Encode([]types.Option[runtime.DynamicInfo]{
+		types.NewOption(pool(0, 0, 0)),
+		types.NewEmptyOption[runtime.DynamicInfo](),
+		types.NewOption(pool(2, 1000e9, 4000e9)),
+	})
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("subnetInfo_getAllDynamicInfo", enc)
+	c := node.NewClient()
+
+	infos, err := runtime.GetAllDynamicInfo(c, nil)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	d := infos[1]
+	require.EqualValues(t, 2, d.Netuid.Int64())
+	require.Equal(t, "apex", d.Name())
+	require.Equal(t, "α", d.Symbol())
+	require.EqualValues(t, 1000e9, d.TaoIn.Int64())
+	require.Equal(t, 0.25, d.MovingPrice.Float64())
+}
+
+func TestGetDynamicInfo(t *testing.T) {
+	enc, err := codec.Encode(types.NewOption(pool(2, 1000e9, 4000e9)))
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("subnetInfo_getDynamicInfo", enc)
+	c := node.NewClient()
+
+	d, err := runtime.GetDynamicInfo(c, 2, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 4000e9, d.AlphaIn.Int64())
+
+	enc, err = codec.Encode(types.NewEmptyOption[runtime.DynamicInfo]())
+	require.NoError(t, err)
+	node.Respond("subnetInfo_getDynamicInfo", enc)
+	_, err = runtime.GetDynamicInfo(c, 5, nil)
+	require.Error(t, err)
+}
+
+func TestDynamicInfoSlippage(t *testing.T) {
+	d := pool(2, 1000e9, 4000e9)
+	require.Equal(t, 0.25, d.Price())
+
+	// 1000 alpha for 250 TAO at spot; the pool gives 4000*250/1250 = 800
+	alpha, slippage := d.StakeSlippage(250e9)
+	require.EqualValues(t, 800e9, alpha)
+	require.EqualValues(t, 200e9, slippage)
+
+	// 250 TAO for 1000 alpha at spot; the pool gives 1000*1000/5000 = 200
+	tao, slippage := d.UnstakeSlippage(1000e9)
+	require.EqualValues(t, 200e9, tao)
+	require.EqualValues(t, 50e9, slippage)
+
+	root := pool(0, 0, 0)
+	require.Equal(t, 1.0, root.Price())
+	alpha, slippage = root.StakeSlippage(5e9)
+	require.EqualValues(t, 5e9, alpha)
+	require.Zero(t, slippage)
+
+	// 1e26 alpha at spot does not fit a uint64; the pool gives 1e27/(1e12+10)
+	thin := pool(2, 10, 1e15)
+	alpha, slippage = thin.StakeSlippage(1e12)
+	require.EqualValues(t, 999_999_999_990_000, alpha)
+	require.EqualValues(t, uint64(math.MaxUint64), slippage)
+}
+
+func TestMovingPriceNegative(t *testing.T) {
+	// -0.5 in two's complement
+	bits := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1<<31))
+	price := typetools.I96F32{Bits: types.NewU128(*bits)}
+	require.Equal(t, -0.5, price.Float64())
+}
@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+type SubnetHyperparams struct {
+	Rho                        types.UCompact // Compact<u16>
+	Kappa                      types.UCompact // Compact<u16>
+	ImmunityPeriod             types.UCompact // Compact<u16>
+	MinAllowedWeights          types.UCompact // Compact<u16>
+	MaxWeightsLimit            types.UCompact // Compact<u16>
+	Tempo                      types.UCompact // Compact<u16>
+	MinDifficulty              types.UCompact // Compact<u64>
+	MaxDifficulty              types.UCompact // Compact<u64>
+	WeightsVersion             types.UCompact // Compact<u64>
+	WeightsRateLimit           types.UCompact // Compact<u64>
+	AdjustmentInterval         types.UCompact // Compact<u16>
+	ActivityCutoff             types.UCompact // Compact<u16>
+	RegistrationAllowed        types.Bool     // bool
+	TargetRegsPerInterval      types.UCompact // Compact<u16>
+	MinBurn                    types.UCompact // Compact<u64>
+	MaxBurn                    types.UCompact // Compact<u64>
+	BondsMovingAvg             types.UCompact // Compact<u64>
+	MaxRegsPerBlock            types.UCompact // Compact<u16>
+	ServingRateLimit           types.UCompact // Compact<u64>
+	MaxValidators              types.UCompact // Compact<u16>
+	AdjustmentAlpha            types.UCompact // Compact<u64>
+	Difficulty                 types.UCompact // Compact<u64>
+	CommitRevealPeriod         types.UCompact // Compact<u64>
+	CommitRevealWeightsEnabled types.Bool     // bool
+	AlphaHigh                  types.UCompact // Compact<u16>
+	AlphaLow                   types.UCompact // Compact<u16>
+	LiquidAlphaEnabled         types.Bool     // bool
+}
+
+func GetSubnetHyperparams(c *client.Client, netuid uint16, block *client.Block) (*SubnetHyperparams, error) {
+	return GetSubnetHyperparamsContext(context.Background(), c, netuid, block)
+}
+
+func GetSubnetHyperparamsContext(ctx context.Context, c *client.Client, netuid uint16, block *client.Block) (*SubnetHyperparams, error) {
+	var params types.Option[SubnetHyperparams]
+	if err := callInfo(ctx, c, &params, "subnetInfo_getSubnetHyperparams", block, netuid); err != nil {
+		return nil, err
+	}
+	ok, p := params.Unwrap()
+	if ok {
+		return &p, nil
+	}
+	return nil, fmt.Errorf("no subnet found for netuid %d", netuid)
+}
+
+// HyperparamChange is a hyperparameter that differs between two
+// SubnetHyperparams. Old and New are uint64 for numbers and bool for flags.
+type HyperparamChange struct {
+	Name string
+	Old  any
+	New  any
+}
+
+// Diff lists the hyperparameters that differ from old to h, in field order
+func (h SubnetHyperparams) Diff(old SubnetHyperparams) []HyperparamChange {
+	var changes []HyperparamChange
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(h)
+	for i := range nv.NumField() {
+		o, n := hyperparamValue(ov.Field(i)), hyperparamValue(nv.Field(i))
+		if o != n {
+			changes = append(changes, HyperparamChange{Name: nv.Type().Field(i).Name, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+func hyperparamValue(v reflect.Value) any {
+	switch v := v.Interface().(type) {
+	case types.UCompact:
+		n := big.Int(v)
+		return n.Uint64()
+	case types.Bool:
+		return bool(v)
+	}
+	panic(fmt.Sprintf("unexpected hyperparameter type %s", v.Type()))
+}
+
+// DiffSubnetHyperparams reports the hyperparameters of a subnet that changed
+// between the from and to blocks.
+func DiffSubnetHyperparams(c *client.Client, netuid uint16, from, to *client.Block) ([]HyperparamChange, error) {
+	return DiffSubnetHyperparamsContext(context.Background(), c, netuid, from, to)
+}
+
+func DiffSubnetHyperparamsContext(ctx context.Context, c *client.Client, netuid uint16, from, to *client.Block) ([]HyperparamChange, error) {
+	old, err := GetSubnetHyperparamsContext(ctx, c, netuid, from)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := GetSubnetHyperparamsContext(ctx, c, netuid, to)
+	if err != nil {
+		return nil, err
+	}
+	return cur.Diff(*old), nil
+}
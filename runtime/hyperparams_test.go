@@ -0,0 +1,58 @@
+package runtime_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/runtime"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestDiffSubnetHyperparams(t *testing.T) {
+	before := runtime.SubnetHyperparams{
+		Tempo:            types.NewUCompactFromUInt(360),
+		WeightsRateLimit: types.NewUCompactFromUInt(100),
+		MinBurn:          types.NewUCompactFromUInt(5e8),
+	}
+	after := before
+	after.Tempo = types.NewUCompactFromUInt(99)
+	after.WeightsRateLimit = types.NewUCompactFromUInt(0)
+	after.LiquidAlphaEnabled = true
+
+	first, second := types.NewHash([]byte("block 1")), types.NewHash([]byte("block 2"))
+	byBlock := map[string]runtime.SubnetHyperparams{first.Hex(): before, second.Hex(): after}
+
+	node := testutils.NewFakeNode(t)
+	node.Handle("subnetInfo_getSubnetHyperparams", func(params []json.RawMessage) (any, error) {
+		var hash string
+		if len(params) != 2 || json.Unmarshal(params[1], &hash) != nil {
+			return nil, fmt.Errorf("unexpected params %s", params)
+		}
+		p, ok := byBlock[hash]
+		if !ok {
+			return codec.Encode(types.NewEmptyOption[runtime.SubnetHyperparams]())
+		}
+		return codec.Encode(types.NewOption(p))
+	})
+	c := node.NewClient()
+
+	got, err := runtime.GetSubnetHyperparams(c, 3, client.AtHash(first))
+	require.NoError(t, err)
+	require.EqualValues(t, 360, got.Tempo.Int64())
+
+	changes, err := runtime.DiffSubnetHyperparams(c, 3, client.AtHash(first), client.AtHash(second))
+	require.NoError(t, err)
+	require.Equal(t, []runtime.HyperparamChange{
+		{Name: "Tempo", Old: uint64(360), New: uint64(99)},
+		{Name: "WeightsRateLimit", Old: uint64(100), New: uint64(0)},
+		{Name: "LiquidAlphaEnabled", Old: false, New: true},
+	}, changes)
+
+	_, err = runtime.GetSubnetHyperparams(c, 3, client.AtHash(types.NewHash([]byte("block 3"))))
+	require.Error(t, err)
+}
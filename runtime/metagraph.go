@@ -1,11 +1,13 @@
 package runtime
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/typetools"
 )
 
 // SubnetIdentityV2 represents identity information for a subnet
@@ -30,10 +32,8 @@ type ChainIdentityOfV2 struct {
 	Additional  types.Bytes
 }
 
-// I96F32 represents a fixed-point number with 96 bits integer part and 32 bits fractional part
-type I96F32 struct {
-	Bits types.U128
-}
+// I96F32 is typetools.I96F32, kept here for existing callers
+type I96F32 = typetools.I96F32
 
 // AccountAmountPair represents a tuple of account and amount
 type AccountAmountPair struct {
@@ -142,17 +142,27 @@ type Metagraph struct {
 }
 
 // GetMetagraph retrieves the metagraph for a specific subnet
-func GetMetagraph(c *client.Client, netuid uint16, blockHash *types.Hash) (*Metagraph, error) {
+func GetMetagraph(c *client.Client, netuid uint16, block *client.Block) (*Metagraph, error) {
+	return GetMetagraphContext(context.Background(), c, netuid, block)
+}
+
+func GetMetagraphContext(ctx context.Context, c *client.Client, netuid uint16, block *client.Block) (*Metagraph, error) {
+	blockHash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
 	// First, try to see what's being returned from the API call
 	var encodedResponse []byte
-	err := c.Api.Client.Call(
+	err = c.CallAt(
+		ctx,
 		&encodedResponse,
 		"subnetInfo_getMetagraph",
-		netuid,
 		blockHash,
+		netuid,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call subnetInfo_getMetagraph: %v", err)
+		return nil, fmt.Errorf("failed to call subnetInfo_getMetagraph: %w", err)
 	}
 
 	if len(encodedResponse) == 0 {
@@ -162,7 +172,7 @@ func GetMetagraph(c *client.Client, netuid uint16, blockHash *types.Hash) (*Meta
 	// Try to decode as a Vec<u8> first
 	var m Metagraph
 	if err := codec.Decode(encodedResponse, &m); err != nil {
-		return nil, fmt.Errorf("failed to decode metagraph: %v", err)
+		return nil, fmt.Errorf("failed to decode metagraph: %w", err)
 	}
 	return &m, nil
 }
@@ -0,0 +1,36 @@
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/runtime"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestGetMetagraph(t *testing.T) {
+	hotkey, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	want := runtime.Metagraph{
+		Netuid:  types.NewUCompactFromUInt(3),
+		Tempo:   types.NewUCompactFromUInt(360),
+		NumUids: types.NewUCompactFromUInt(1),
+		Hotkeys: []types.AccountID{*hotkey},
+	}
+	enc, err := codec.Encode(want)
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("subnetInfo_getMetagraph", enc)
+	c := node.NewClient()
+
+	m, err := runtime.GetMetagraph(c, 3, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, m.Netuid.Int64())
+	require.EqualValues(t, 360, m.Tempo.Int64())
+	require.Equal(t, want.Hotkeys, m.Hotkeys)
+}
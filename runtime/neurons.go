@@ -1,6 +1,7 @@
 package runtime
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -60,16 +61,52 @@ type NeuronInfo struct {
 	PruningScore types.UCompact
 }
 
-func GetNeurons(c *client.Client, netuid uint16, blockHash *types.Hash) ([]NeuronInfo, error) {
+// NeuronInfoLite is NeuronInfo without weights and bonds, which make up
+// most of a neuron on large subnets.
+type NeuronInfoLite struct {
+	Hotkey         types.AccountID
+	Coldkey        types.AccountID
+	UID            types.UCompact
+	NetUID         types.UCompact
+	Active         types.Bool
+	AxonInfo       AxonInfo
+	PrometheusInfo PrometheusInfo
+	Stake          []struct {
+		Account types.AccountID
+		Amount  types.UCompact
+	}
+	Rank            types.UCompact
+	Emission        types.UCompact
+	Incentive       types.UCompact
+	Consensus       types.UCompact
+	Trust           types.UCompact
+	ValidatorTrust  types.UCompact
+	Dividends       types.UCompact
+	LastUpdate      types.UCompact
+	ValidatorPermit types.Bool
+	PruningScore    types.UCompact
+}
+
+func GetNeurons(c *client.Client, netuid uint16, block *client.Block) ([]NeuronInfo, error) {
+	return GetNeuronsContext(context.Background(), c, netuid, block)
+}
+
+func GetNeuronsContext(ctx context.Context, c *client.Client, netuid uint16, block *client.Block) ([]NeuronInfo, error) {
+	blockHash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
 	var encodedResponse []byte
-	err := c.Api.Client.Call(
+	err = c.CallAt(
+		ctx,
 		&encodedResponse,
 		"neuronInfo_getNeurons",
+		blockHash,
 		netuid,
-		*blockHash,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call neuronInfo_getNeurons: %v", err)
+		return nil, fmt.Errorf("failed to call neuronInfo_getNeurons: %w", err)
 	}
 
 	if len(encodedResponse) == 0 {
@@ -79,23 +116,33 @@ func GetNeurons(c *client.Client, netuid uint16, blockHash *types.Hash) ([]Neuro
 	var neurons []NeuronInfo
 	err = codec.Decode(encodedResponse, &neurons)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode neurons: %v", err)
+		return nil, fmt.Errorf("failed to decode neurons: %w", err)
 	}
 
 	return neurons, nil
 }
 
-func GetNeuron(c *client.Client, netuid uint16, uid uint16, blockHash *types.Hash) (*NeuronInfo, error) {
+func GetNeuron(c *client.Client, netuid uint16, uid uint16, block *client.Block) (*NeuronInfo, error) {
+	return GetNeuronContext(context.Background(), c, netuid, uid, block)
+}
+
+func GetNeuronContext(ctx context.Context, c *client.Client, netuid uint16, uid uint16, block *client.Block) (*NeuronInfo, error) {
+	blockHash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
 	var encodedResponse []byte
-	err := c.Api.Client.Call(
+	err = c.CallAt(
+		ctx,
 		&encodedResponse,
 		"neuronInfo_getNeuron",
+		blockHash,
 		netuid,
 		uid,
-		*blockHash,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call neuronInfo_getNeurons: %v", err)
+		return nil, fmt.Errorf("failed to call neuronInfo_getNeuron: %w", err)
 	}
 
 	if len(encodedResponse) == 0 {
@@ -105,7 +152,35 @@ func GetNeuron(c *client.Client, netuid uint16, uid uint16, blockHash *types.Has
 	var neuron types.Option[NeuronInfo]
 	err = codec.Decode(encodedResponse, &neuron)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode neurons: %v", err)
+		return nil, fmt.Errorf("failed to decode neurons: %w", err)
+	}
+	ok, n := neuron.Unwrap()
+	if ok {
+		return &n, nil
+	}
+	return nil, errors.New("no neuron found")
+}
+
+func GetNeuronsLite(c *client.Client, netuid uint16, block *client.Block) ([]NeuronInfoLite, error) {
+	return GetNeuronsLiteContext(context.Background(), c, netuid, block)
+}
+
+func GetNeuronsLiteContext(ctx context.Context, c *client.Client, netuid uint16, block *client.Block) ([]NeuronInfoLite, error) {
+	var neurons []NeuronInfoLite
+	if err := callInfo(ctx, c, &neurons, "neuronInfo_getNeuronsLite", block, netuid); err != nil {
+		return nil, err
+	}
+	return neurons, nil
+}
+
+func GetNeuronLite(c *client.Client, netuid uint16, uid uint16, block *client.Block) (*NeuronInfoLite, error) {
+	return GetNeuronLiteContext(context.Background(), c, netuid, uid, block)
+}
+
+func GetNeuronLiteContext(ctx context.Context, c *client.Client, netuid uint16, uid uint16, block *client.Block) (*NeuronInfoLite, error) {
+	var neuron types.Option[NeuronInfoLite]
+	if err := callInfo(ctx, c, &neuron, "neuronInfo_getNeuronLite", block, netuid, uid); err != nil {
+		return nil, err
 	}
 	ok, n := neuron.Unwrap()
 	if ok {
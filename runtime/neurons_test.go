@@ -0,0 +1,156 @@
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/runtime"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestGetNeuronsContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	node := testutils.NewFakeNode(t)
+	node.Handle("neuronInfo_getNeurons", func([]json.RawMessage) (any, error) {
+		<-release
+		return []byte{}, nil
+	})
+
+	c, err := client.NewClient(node.URL())
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+
+	_, err = runtime.GetNeuronsContext(ctx, c, 1, nil)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, context.Canceled), err.Error())
+}
+
+// subnetNeurons builds a full subnet of n neurons that all set weights on
+// and hold bonds to every uid, the worst case for decoding NeuronInfo.
+func subnetNeurons(n int) []runtime.NeuronInfo {
+	neurons := make([]runtime.NeuronInfo, n)
+	for i := range neurons {
+		nrn := &neurons[i]
+		nrn.Hotkey[0], nrn.Hotkey[1] = byte(i), byte(i>>8)
+		nrn.Coldkey[0], nrn.Coldkey[1] = byte(i), 0xff
+		nrn.UID = types.NewUCompactFromUInt(uint64(i))
+		nrn.NetUID = types.NewUCompactFromUInt(3)
+		nrn.Active = true
+		nrn.Stake = append(nrn.Stake, struct {
+			Account types.AccountID
+			Amount  types.UCompact
+		}{nrn.Coldkey, types.NewUCompactFromUInt(uint64(i) * 1e9)})
+		for _, v := range []*types.UCompact{&nrn.Rank, &nrn.Emission, &nrn.Incentive, &nrn.Consensus, &nrn.Trust, &nrn.ValidatorTrust, &nrn.Dividends, &nrn.LastUpdate, &nrn.PruningScore} {
+			*v = types.NewUCompactFromUInt(uint64(i*257) % 65536)
+		}
+		for j := range n {
+			uid := types.NewUCompactFromUInt(uint64(j))
+			nrn.Weights = append(nrn.Weights, struct {
+				UID    types.UCompact
+				Weight types.UCompact
+			}{uid, types.NewUCompactFromUInt(uint64(i+j) % 65536)})
+			nrn.Bonds = append(nrn.Bonds, struct {
+				UID  types.UCompact
+				Bond types.UCompact
+			}{uid, types.NewUCompactFromUInt(uint64(i*j) % 65536)})
+		}
+	}
+	return neurons
+}
+
+func liteNeurons(neurons []runtime.NeuronInfo) []runtime.NeuronInfoLite {
+	lite := make([]runtime.NeuronInfoLite, len(neurons))
+	for i, n := range neurons {
+		lite[i] = runtime.NeuronInfoLite{
+			Hotkey: n.Hotkey, Coldkey: n.Coldkey, UID: n.UID, NetUID: n.NetUID, Active: n.Active,
+			AxonInfo: n.AxonInfo, PrometheusInfo: n.PrometheusInfo, Stake: n.Stake,
+			Rank: n.Rank, Emission: n.Emission, Incentive: n.Incentive, Consensus: n.Consensus,
+			Trust: n.Trust, ValidatorTrust: n.ValidatorTrust, Dividends: n.Dividends,
+			LastUpdate: n.LastUpdate, ValidatorPermit: n.ValidatorPermit, PruningScore: n.PruningScore,
+		}
+	}
+	return lite
+}
+
+func TestGetNeuronsLite(t *testing.T) {
+	want := liteNeurons(subnetNeurons(4))
+	enc, err := codec.Encode(want)
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("neuronInfo_getNeuronsLite", enc)
+	c := node.NewClient()
+
+	neurons, err := runtime.GetNeuronsLite(c, 3, nil)
+	require.NoError(t, err)
+	// compared encoded, big.Int zeros differ after a round trip
+	got, err := codec.Encode(neurons)
+	require.NoError(t, err)
+	require.Equal(t, enc, got)
+}
+
+func TestGetNeuronLite(t *testing.T) {
+	want := liteNeurons(subnetNeurons(2))[1]
+	enc, err := codec.Encode(types.NewOption(want))
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("neuronInfo_getNeuronLite", enc)
+	c := node.NewClient()
+
+	neuron, err := runtime.GetNeuronLite(c, 3, 1, nil)
+	require.NoError(t, err)
+	got, err := codec.Encode(types.NewOption(*neuron))
+	require.NoError(t, err)
+	require.Equal(t, enc, got)
+
+	enc, err = codec.Encode(types.NewEmptyOption[runtime.NeuronInfoLite]())
+	require.NoError(t, err)
+	node.Respond("neuronInfo_getNeuronLite", enc)
+	_, err = runtime.GetNeuronLite(c, 3, 2, nil)
+	require.Error(t, err)
+}
+
+// encodedSubnet encodes a 256 uid subnet built by subnetNeurons. It is
+// synthetic and denser than real subnets, where only validators set weights.
+func encodedSubnet(b *testing.B) []byte {
+	enc, err := codec.Encode(subnetNeurons(256))
+	require.NoError(b, err)
+	return enc
+}
+
+func BenchmarkDecodeNeurons(b *testing.B) {
+	enc := encodedSubnet(b)
+	b.SetBytes(int64(len(enc)))
+	b.ResetTimer()
+	for range b.N {
+		var neurons []runtime.NeuronInfo
+		if err := codec.Decode(enc, &neurons); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeNeuronsLite(b *testing.B) {
+	enc, err := codec.Encode(liteNeurons(subnetNeurons(256)))
+	require.NoError(b, err)
+	b.SetBytes(int64(len(enc)))
+	b.ResetTimer()
+	for range b.N {
+		var neurons []runtime.NeuronInfoLite
+		if err := codec.Decode(enc, &neurons); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
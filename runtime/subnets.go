@@ -0,0 +1,106 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+type SubnetInfo struct {
+	Netuid               types.UCompact  // Compact<u16>
+	Rho                  types.UCompact  // Compact<u16>
+	Kappa                types.UCompact  // Compact<u16>
+	Difficulty           types.UCompact  // Compact<u64>
+	ImmunityPeriod       types.UCompact  // Compact<u16>
+	MaxAllowedValidators types.UCompact  // Compact<u16>
+	MinAllowedWeights    types.UCompact  // Compact<u16>
+	MaxWeightsLimit      types.UCompact  // Compact<u16>
+	ScalingLawPower      types.UCompact  // Compact<u16>
+	SubnetworkN          types.UCompact  // Compact<u16>
+	MaxAllowedUids       types.UCompact  // Compact<u16>
+	BlocksSinceLastStep  types.UCompact  // Compact<u64>
+	Tempo                types.UCompact  // Compact<u16>
+	NetworkModality      types.UCompact  // Compact<u16>
+	NetworkConnect       [][2]types.U16  // Vec<[u16; 2]>
+	EmissionValues       types.UCompact  // Compact<u64>
+	Burn                 types.UCompact  // Compact<u64>
+	Owner                types.AccountID // AccountId
+}
+
+// SubnetInfoV2 is SubnetInfo with the subnet's identity
+type SubnetInfoV2 struct {
+	SubnetInfo
+	Identity types.Option[SubnetIdentityV2] // Option<SubnetIdentityV2>
+}
+
+func GetSubnetInfo(c *client.Client, netuid uint16, block *client.Block) (*SubnetInfo, error) {
+	return GetSubnetInfoContext(context.Background(), c, netuid, block)
+}
+
+func GetSubnetInfoContext(ctx context.Context, c *client.Client, netuid uint16, block *client.Block) (*SubnetInfo, error) {
+	var info types.Option[SubnetInfo]
+	if err := callInfo(ctx, c, &info, "subnetInfo_getSubnetInfo", block, netuid); err != nil {
+		return nil, err
+	}
+	ok, s := info.Unwrap()
+	if ok {
+		return &s, nil
+	}
+	return nil, fmt.Errorf("no subnet found for netuid %d", netuid)
+}
+
+// GetSubnetsInfo returns every existing subnet, in netuid order
+func GetSubnetsInfo(c *client.Client, block *client.Block) ([]SubnetInfo, error) {
+	return GetSubnetsInfoContext(context.Background(), c, block)
+}
+
+func GetSubnetsInfoContext(ctx context.Context, c *client.Client, block *client.Block) ([]SubnetInfo, error) {
+	var infos []types.Option[SubnetInfo]
+	if err := callInfo(ctx, c, &infos, "subnetInfo_getSubnetsInfo", block); err != nil {
+		return nil, err
+	}
+	return existing(infos), nil
+}
+
+func GetSubnetInfoV2(c *client.Client, netuid uint16, block *client.Block) (*SubnetInfoV2, error) {
+	return GetSubnetInfoV2Context(context.Background(), c, netuid, block)
+}
+
+func GetSubnetInfoV2Context(ctx context.Context, c *client.Client, netuid uint16, block *client.Block) (*SubnetInfoV2, error) {
+	var info types.Option[SubnetInfoV2]
+	if err := callInfo(ctx, c, &info, "subnetInfo_getSubnetInfo_v2", block, netuid); err != nil {
+		return nil, err
+	}
+	ok, s := info.Unwrap()
+	if ok {
+		return &s, nil
+	}
+	return nil, fmt.Errorf("no subnet found for netuid %d", netuid)
+}
+
+// GetSubnetsInfoV2 returns every existing subnet, in netuid order
+func GetSubnetsInfoV2(c *client.Client, block *client.Block) ([]SubnetInfoV2, error) {
+	return GetSubnetsInfoV2Context(context.Background(), c, block)
+}
+
+func GetSubnetsInfoV2Context(ctx context.Context, c *client.Client, block *client.Block) ([]SubnetInfoV2, error) {
+	var infos []types.Option[SubnetInfoV2]
+	// the node registers this method under a misspelled name
+	if err := callInfo(ctx, c, &infos, "subnetInfo_getSubnetsInf_v2", block); err != nil {
+		return nil, err
+	}
+	return existing(infos), nil
+}
+
+// existing drops the None entries the node returns for dissolved netuids
+func existing[T any](opts []types.Option[T]) []T {
+	res := make([]T, 0, len(opts))
+	for _, opt := range opts {
+		if ok, v := opt.Unwrap(); ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
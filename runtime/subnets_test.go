@@ -0,0 +1,90 @@
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/runtime"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func testSubnet(netuid uint64) runtime.SubnetInfo {
+	return runtime.SubnetInfo{
+		Netuid:         types.NewUCompactFromUInt(netuid),
+		Rho:            types.NewUCompactFromUInt(10),
+		Kappa:          types.NewUCompactFromUInt(32767),
+		Difficulty:     types.NewUCompactFromUInt(10_000_000),
+		Tempo:          types.NewUCompactFromUInt(360),
+		NetworkConnect: [][2]types.U16{{1, 2}},
+		Burn:           types.NewUCompactFromUInt(1e9),
+		Owner:          types.AccountID{byte(netuid)},
+	}
+}
+
+func TestGetSubnetsInfo(t *testing.T) {
+	enc, err := codec.Encode([]types.Option[runtime.SubnetInfo]{
+		types.NewOption(testSubnet(1)),
+		types.NewEmptyOption[runtime.SubnetInfo](),
+		types.NewOption(testSubnet(3)),
+	})
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("subnetInfo_getSubnetsInfo", enc)
+	c := node.NewClient()
+
+	subnets, err := runtime.GetSubnetsInfo(c, nil)
+	require.NoError(t, err)
+	require.Len(t, subnets, 2, "dissolved netuids are skipped")
+	require.EqualValues(t, 1, subnets[0].Netuid.Int64())
+	require.EqualValues(t, 3, subnets[1].Netuid.Int64())
+	require.EqualValues(t, 360, subnets[1].Tempo.Int64())
+	require.Equal(t, [][2]types.U16{{1, 2}}, subnets[1].NetworkConnect)
+	require.Equal(t, types.AccountID{3}, subnets[1].Owner)
+}
+
+func TestGetSubnetInfoV2(t *testing.T) {
+	want := runtime.SubnetInfoV2{
+		SubnetInfo: testSubnet(3),
+		Identity:   types.NewOption(runtime.SubnetIdentityV2{SubnetName: types.Bytes("apex")}),
+	}
+	enc, err := codec.Encode(types.NewOption(want))
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("subnetInfo_getSubnetInfo_v2", enc)
+	c := node.NewClient()
+
+	subnet, err := runtime.GetSubnetInfoV2(c, 3, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, subnet.Netuid.Int64())
+	ok, identity := subnet.Identity.Unwrap()
+	require.True(t, ok)
+	require.Equal(t, "apex", string(identity.SubnetName))
+
+	enc, err = codec.Encode(types.NewEmptyOption[runtime.SubnetInfoV2]())
+	require.NoError(t, err)
+	node.Respond("subnetInfo_getSubnetInfo_v2", enc)
+	_, err = runtime.GetSubnetInfoV2(c, 4, nil)
+	require.Error(t, err)
+}
+
+func TestGetSubnetsInfoV2(t *testing.T) {
+	enc, err := codec.Encode([]types.Option[runtime.SubnetInfoV2]{
+		types.NewEmptyOption[runtime.SubnetInfoV2](),
+		types.NewOption(runtime.SubnetInfoV2{SubnetInfo: testSubnet(1)}),
+	})
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("subnetInfo_getSubnetsInf_v2", enc)
+	c := node.NewClient()
+
+	subnets, err := runtime.GetSubnetsInfoV2(c, nil)
+	require.NoError(t, err)
+	require.Len(t, subnets, 1)
+	require.EqualValues(t, 1, subnets[0].Netuid.Int64())
+	require.False(t, subnets[0].Identity.HasValue())
+}
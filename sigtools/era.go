@@ -0,0 +1,98 @@
+package sigtools
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+// DefaultEraPeriod is how many blocks a mortal extrinsic stays valid for when
+// the signing context does not set a period.
+const DefaultEraPeriod = 64
+
+// blockTime is the target time between subtensor blocks
+const blockTime = 12 * time.Second
+
+// EraCheckpoint is the block a mortal era is anchored to.
+type EraCheckpoint struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// MortalEra encodes an era of at least period blocks starting at block
+// current, the way the runtime expects it. The period is rounded up to a
+// power of two between 4 and 65536. It also returns the block the era is born
+// at, whose hash must be signed. That is current itself unless the period is
+// above 4096, where the phase loses precision.
+func MortalEra(period, current uint64) (types.ExtrinsicEra, uint64) {
+	// clamped first: rounding up periods above 2^63 overflows to 0
+	period = min(max(period, 4), 1<<16)
+	period = 1 << bits.Len64(period-1)
+
+	phase := current % period
+	quantizeFactor := max(period>>12, 1)
+	quantizedPhase := phase / quantizeFactor * quantizeFactor
+
+	encoded := uint16(min(15, max(1, bits.TrailingZeros64(period)-1))) | uint16(quantizedPhase/quantizeFactor)<<4
+	birth := (max(current, quantizedPhase)-quantizedPhase)/period*period + quantizedPhase
+
+	era := types.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: types.MortalEra{First: byte(encoded), Second: byte(encoded >> 8)},
+	}
+	return era, birth
+}
+
+// signingEra returns the era and the block hash to sign it with. Online
+// clients anchor mortal eras on the finalized block, which the client follows
+// or, over HTTP, looks up at most once per era period. Offline clients need a
+// checkpoint in the signing context.
+func signingEra(ctx context.Context, c *client.Client, sc *SigningContext, genesisHash types.Hash) (types.ExtrinsicEra, types.Hash, error) {
+	if sc != nil && sc.Immortal {
+		return types.ExtrinsicEra{IsImmortalEra: true}, genesisHash, nil
+	}
+
+	period := uint64(DefaultEraPeriod)
+	if sc != nil && sc.EraPeriod != 0 {
+		period = sc.EraPeriod
+	}
+
+	var checkpoint EraCheckpoint
+	switch {
+	case sc != nil && sc.Checkpoint != nil:
+		checkpoint = *sc.Checkpoint
+	case c.Offline():
+		return types.ExtrinsicEra{}, types.Hash{}, fmt.Errorf("era checkpoint must be set in the signing context when offline, or the era made immortal")
+	default:
+		head, age, err := c.FinalizedHeadContext(ctx, time.Duration(period)*blockTime)
+		if err != nil {
+			return types.ExtrinsicEra{}, types.Hash{}, err
+		}
+		checkpoint = EraCheckpoint(head)
+		// an older checkpoint stretches the era by the blocks since, so the
+		// extrinsic still lives for period blocks
+		period += uint64(age / blockTime)
+	}
+
+	era, birth := MortalEra(period, checkpoint.Number)
+	if birth == checkpoint.Number {
+		return era, checkpoint.Hash, nil
+	}
+	if c.Offline() {
+		return types.ExtrinsicEra{}, types.Hash{}, fmt.Errorf("era of %d blocks is born at block %d, use a checkpoint at that block", period, birth)
+	}
+
+	var hashHex string
+	if err := c.Api.Client.CallContext(ctx, &hashHex, "chain_getBlockHash", birth); err != nil {
+		return types.ExtrinsicEra{}, types.Hash{}, fmt.Errorf("failed to get hash of era birth block %d: %w", birth, err)
+	}
+	hash, err := types.NewHashFromHexString(hashHex)
+	if err != nil {
+		return types.ExtrinsicEra{}, types.Hash{}, fmt.Errorf("failed to decode hash of era birth block %d: %w", birth, err)
+	}
+	return era, hash, nil
+}
@@ -0,0 +1,98 @@
+package sigtools_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/sigtools"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestMortalEra(t *testing.T) {
+	tests := []struct {
+		name          string
+		period        uint64
+		current       uint64
+		first, second byte
+		birth         uint64
+	}{
+		{"exact period", 64, 42, 0xa5, 0x02, 42},
+		{"rounded up to a power of two", 50, 42, 0xa5, 0x02, 42},
+		{"minimum period", 1, 10, 0x21, 0x00, 10},
+		{"quantized phase", 32768, 20003, 0x4e, 0x9c, 20000},
+		{"clamped period", 1_000_000, 1_000_005, 0x4f, 0x42, 1_000_000},
+		{"period above 2^63", 1<<63 + 1, 5, 0x0f, 0x00, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			era, birth := sigtools.MortalEra(tt.period, tt.current)
+			require.True(t, era.IsMortalEra)
+			require.Equal(t, types.MortalEra{First: tt.first, Second: tt.second}, era.AsMortalEra)
+			require.Equal(t, tt.birth, birth)
+		})
+	}
+}
+
+func signedFields(t *testing.T, opts []extrinsic.SigningOption) extrinsic.SignedFieldValues {
+	vals := extrinsic.SignedFieldValues{}
+	for _, opt := range opts {
+		opt(vals)
+	}
+	return vals
+}
+
+func TestCreateSigningOptionsMortalByDefault(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	finalized := types.NewHash([]byte("block 100"))
+	node.SetFinalizedHead(100, finalized)
+	c := node.NewClient()
+
+	nonce := types.NewUCompactFromUInt(0)
+	opts, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, &sigtools.SigningContext{Nonce: &nonce})
+	require.NoError(t, err)
+
+	want, _ := sigtools.MortalEra(sigtools.DefaultEraPeriod, 100)
+	vals := signedFields(t, opts)
+	require.Equal(t, want, vals[extrinsic.EraSignedField])
+	require.Equal(t, finalized, vals[extrinsic.BlockHashSignedField])
+}
+
+func TestCreateSigningOptionsLongEraUsesBirthBlock(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	node.SetFinalizedHead(20003, types.NewHash([]byte("block 20003")))
+	c := node.NewClient()
+
+	nonce := types.NewUCompactFromUInt(0)
+	sc := &sigtools.SigningContext{Nonce: &nonce, EraPeriod: 32768}
+	opts, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, sc)
+	require.NoError(t, err)
+
+	// the fake node reports the genesis hash for every block number
+	require.Equal(t, testutils.FakeGenesisHash, signedFields(t, opts)[extrinsic.BlockHashSignedField])
+}
+
+func TestCreateSigningOptionsImmortal(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	node.SetFinalizedHead(100, types.NewHash([]byte("block 100")))
+	c := node.NewClient()
+
+	nonce := types.NewUCompactFromUInt(0)
+	sc := &sigtools.SigningContext{Nonce: &nonce, Immortal: true}
+	opts, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, sc)
+	require.NoError(t, err)
+
+	vals := signedFields(t, opts)
+	require.Equal(t, types.ExtrinsicEra{IsImmortalEra: true}, vals[extrinsic.EraSignedField])
+	require.Equal(t, testutils.FakeGenesisHash, vals[extrinsic.BlockHashSignedField])
+}
+
+func TestCreateSigningOptionsOfflineRequiresCheckpoint(t *testing.T) {
+	c := offlineClient(t)
+
+	nonce := types.NewUCompactFromUInt(0)
+	_, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, &sigtools.SigningContext{Nonce: &nonce})
+	require.ErrorContains(t, err, "checkpoint")
+}
@@ -0,0 +1,104 @@
+package sigtools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+// NonceManager hands out nonces to accounts that submit several extrinsics
+// without waiting for each one to be included. The first nonce of an account
+// comes from system_accountNextIndex, which counts the account's transactions
+// still in the pool, later ones are counted locally. It is safe for
+// concurrent use.
+type NonceManager struct {
+	c *client.Client
+
+	mu       sync.Mutex
+	accounts map[types.AccountID]*accountNonce
+}
+
+type accountNonce struct {
+	mu     sync.Mutex
+	next   uint64
+	synced bool
+}
+
+func NewNonceManager(c *client.Client) *NonceManager {
+	return &NonceManager{
+		c:        c,
+		accounts: map[types.AccountID]*accountNonce{},
+	}
+}
+
+// Next returns the nonce to sign the next extrinsic of acc with. Nonces of an
+// account increase by one with every call until the account is resynced.
+func (m *NonceManager) Next(ctx context.Context, acc types.AccountID) (uint64, error) {
+	a := m.account(acc)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.synced {
+		next, err := accountNextIndex(ctx, m.c, acc)
+		if err != nil {
+			return 0, err
+		}
+		a.next = next
+		a.synced = true
+	}
+
+	nonce := a.next
+	a.next++
+	return nonce, nil
+}
+
+// Resync drops the local count for acc, the next call to Next asks the node
+// again. Nonces handed out after a dropped or invalid extrinsic would
+// otherwise leave a gap the chain never fills.
+func (m *NonceManager) Resync(acc types.AccountID) {
+	a := m.account(acc)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.synced = false
+}
+
+// ObserveStatus resyncs acc when status shows that its extrinsic will not be
+// included. Feed it the statuses from author_submitAndWatchExtrinsic.
+func (m *NonceManager) ObserveStatus(acc types.AccountID, status types.ExtrinsicStatus) {
+	if status.IsDropped || status.IsInvalid || status.IsUsurped {
+		m.Resync(acc)
+	}
+}
+
+// ObserveSubmit resyncs acc if submitting its extrinsic failed, for instance
+// because the node rejected the nonce as stale.
+func (m *NonceManager) ObserveSubmit(acc types.AccountID, err error) {
+	if err != nil {
+		m.Resync(acc)
+	}
+}
+
+func (m *NonceManager) account(acc types.AccountID) *accountNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.accounts[acc]
+	if !ok {
+		a = &accountNonce{}
+		m.accounts[acc] = a
+	}
+	return a
+}
+
+// accountNextIndex returns the next nonce of acc, counting its transactions
+// in the pool.
+func accountNextIndex(ctx context.Context, c *client.Client, acc types.AccountID) (uint64, error) {
+	var next uint64
+	err := c.Api.Client.CallContext(ctx, &next, "system_accountNextIndex", c.SS58(acc))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next nonce for %s: %w", c.SS58(acc), err)
+	}
+	return next, nil
+}
@@ -0,0 +1,120 @@
+package sigtools_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/sigtools"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func nonceNode(t *testing.T, next *atomic.Uint64, lookups *atomic.Int64) *testutils.FakeNode {
+	node := testutils.NewFakeNode(t)
+	node.Handle("system_accountNextIndex", func(params []json.RawMessage) (any, error) {
+		lookups.Add(1)
+		return next.Load(), nil
+	})
+	return node
+}
+
+func TestNonceManagerConcurrent(t *testing.T) {
+	var next atomic.Uint64
+	var lookups atomic.Int64
+	next.Store(10)
+	c := nonceNode(t, &next, &lookups).NewClient()
+
+	alice, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	m := sigtools.NewNonceManager(c)
+	var mu sync.Mutex
+	seen := map[uint64]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := m.Next(context.Background(), *alice)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			seen[n] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, seen, 50)
+	for n := uint64(10); n < 60; n++ {
+		require.True(t, seen[n], "nonce %d was not handed out", n)
+	}
+	require.EqualValues(t, 1, lookups.Load())
+}
+
+func TestNonceManagerResync(t *testing.T) {
+	var next atomic.Uint64
+	var lookups atomic.Int64
+	next.Store(3)
+	c := nonceNode(t, &next, &lookups).NewClient()
+
+	alice, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+	bob, err := types.NewAccountID(make([]byte, 32))
+	require.NoError(t, err)
+
+	m := sigtools.NewNonceManager(c)
+	ctx := context.Background()
+	for want := uint64(3); want < 6; want++ {
+		n, err := m.Next(ctx, *alice)
+		require.NoError(t, err)
+		require.Equal(t, want, n)
+	}
+
+	// nonce 4 was dropped, the pool only holds 3
+	m.ObserveStatus(*alice, types.ExtrinsicStatus{IsDropped: true})
+	next.Store(4)
+	n, err := m.Next(ctx, *alice)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, n)
+
+	// statuses that keep the extrinsic alive do not resync
+	m.ObserveStatus(*alice, types.ExtrinsicStatus{IsReady: true})
+	m.ObserveSubmit(*alice, nil)
+	n, err = m.Next(ctx, *alice)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, n)
+
+	// accounts are counted separately
+	n, err = m.Next(ctx, *bob)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, n)
+	require.EqualValues(t, 3, lookups.Load())
+}
+
+func TestCreateSigningOptionsWithNonceManager(t *testing.T) {
+	var next atomic.Uint64
+	var lookups atomic.Int64
+	next.Store(7)
+	c := nonceNode(t, &next, &lookups).NewClient()
+
+	m := sigtools.NewNonceManager(c)
+	sc := &sigtools.SigningContext{Nonces: m}
+	for i := 0; i < 2; i++ {
+		_, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, sc)
+		require.NoError(t, err)
+	}
+
+	alice, err := types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+	n, err := m.Next(context.Background(), *alice)
+	require.NoError(t, err)
+	require.EqualValues(t, 9, n)
+}
@@ -1,6 +1,8 @@
 package sigtools
 
 import (
+	"context"
+	"fmt"
 	"math/big"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
@@ -8,7 +10,6 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
 	"github.com/subtrahend-labs/gobt/client"
-	"github.com/subtrahend-labs/gobt/storage"
 )
 
 func init() {
@@ -20,6 +21,19 @@ func init() {
 type SigningContext struct {
 	Tip   *types.UCompact
 	Nonce *types.UCompact
+	// Nonces is used when Nonce is not set. Without either, every signing
+	// asks the node for the account's next nonce.
+	Nonces *NonceManager
+
+	// EraPeriod is how many blocks the extrinsic stays valid for, zero means
+	// DefaultEraPeriod.
+	EraPeriod uint64
+	// Checkpoint anchors the era. Online clients default to the current
+	// finalized block, offline clients must set it.
+	Checkpoint *EraCheckpoint
+	// Immortal signs an extrinsic that never expires, so it can be replayed
+	// if the account is reaped and its nonce starts over.
+	Immortal bool
 }
 
 func NewSigningContext(t *types.UCompact, n *types.UCompact) *SigningContext {
@@ -27,6 +41,10 @@ func NewSigningContext(t *types.UCompact, n *types.UCompact) *SigningContext {
 }
 
 func CreateSigningOptions(c *client.Client, keypair signature.KeyringPair, sc *SigningContext) ([]extrinsic.SigningOption, error) {
+	return CreateSigningOptionsContext(context.Background(), c, keypair, sc)
+}
+
+func CreateSigningOptionsContext(ctx context.Context, c *client.Client, keypair signature.KeyringPair, sc *SigningContext) ([]extrinsic.SigningOption, error) {
 	var options []extrinsic.SigningOption
 
 	// tip
@@ -42,18 +60,38 @@ func CreateSigningOptions(c *client.Client, keypair signature.KeyringPair, sc *S
 	nonce := types.NewUCompact(big.NewInt(0))
 	if sc != nil && sc.Nonce != nil {
 		nonce = *sc.Nonce
-	} else if s, err := storage.GetAccountInfo(c, keypair.PublicKey, nil); err == nil {
-		nonce = types.NewUCompactFromUInt(uint64(s.Nonce))
+	} else if sc != nil && sc.Nonces != nil {
+		acc, err := types.NewAccountID(keypair.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer public key: %w", err)
+		}
+		n, err := sc.Nonces.Next(ctx, *acc)
+		if err != nil {
+			return nil, err
+		}
+		nonce = types.NewUCompactFromUInt(n)
+	} else if c.Offline() {
+		return nil, fmt.Errorf("nonce must be set in the signing context when offline")
+	} else {
+		acc, err := types.NewAccountID(keypair.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer public key: %w", err)
+		}
+		n, err := accountNextIndex(ctx, c, *acc)
+		if err != nil {
+			return nil, err
+		}
+		nonce = types.NewUCompactFromUInt(n)
 	}
 	options = append(options,
 		extrinsic.WithNonce(nonce),
 	)
 
+	// Both are cached on the client and follow runtime upgrades
+	rv := c.RuntimeVersion()
+	genesisHash := c.GenesisHash()
+
 	// Spec & transaction Version
-	rv, err := c.Api.RPC.State.GetRuntimeVersionLatest()
-	if err != nil {
-		return nil, err
-	}
 	options = append(options,
 		extrinsic.WithSpecVersion(rv.SpecVersion),
 	)
@@ -67,16 +105,15 @@ func CreateSigningOptions(c *client.Client, keypair signature.KeyringPair, sc *S
 	)
 
 	// Genesis and era
-	genesisHash, err := c.Api.RPC.Chain.GetBlockHash(0)
-	if err != nil {
-		return nil, err
-	}
-
 	options = append(options,
 		extrinsic.WithGenesisHash(genesisHash),
 	)
+	era, checkpoint, err := signingEra(ctx, c, sc, genesisHash)
+	if err != nil {
+		return nil, err
+	}
 	options = append(options,
-		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, genesisHash),
+		extrinsic.WithEra(era, checkpoint),
 	)
 
 	return options, nil
@@ -0,0 +1,113 @@
+package sigtools_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/extrinsics"
+	"github.com/subtrahend-labs/gobt/sigtools"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func offlineClient(t *testing.T) *client.Client {
+	var meta types.Metadata
+	require.NoError(t, codec.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	c, err := client.NewOfflineClient(&meta, client.OfflineParams{
+		GenesisHash:        types.NewHash([]byte{1, 2, 3}),
+		SpecVersion:        1,
+		TransactionVersion: 1,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestCreateSigningOptionsOffline(t *testing.T) {
+	c := offlineClient(t)
+	alice := signature.TestKeyringPairAlice
+
+	dest, err := types.NewMultiAddressFromAccountID(alice.PublicKey)
+	require.NoError(t, err)
+	ext, err := extrinsics.TransferKeepAliveExt(c, dest, types.NewUCompactFromUInt(1000))
+	require.NoError(t, err)
+
+	tip := types.NewUCompactFromUInt(0)
+	nonce := types.NewUCompactFromUInt(5)
+	sc := sigtools.NewSigningContext(&tip, &nonce)
+	sc.Checkpoint = &sigtools.EraCheckpoint{Number: 10, Hash: types.NewHash([]byte{4, 5, 6})}
+	opts, err := sigtools.CreateSigningOptions(c, alice, sc)
+	require.NoError(t, err)
+
+	require.NoError(t, ext.Sign(alice, c.Metadata(), opts...))
+	require.True(t, ext.IsSigned())
+}
+
+func TestCreateSigningOptionsOfflineRequiresNonce(t *testing.T) {
+	c := offlineClient(t)
+
+	_, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, nil)
+	require.Error(t, err)
+}
+
+func TestCreateSigningOptionsUsesCachedConstants(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	node.Handle("system_accountNextIndex", func([]json.RawMessage) (any, error) {
+		return 4, nil
+	})
+	hash := types.NewHash([]byte("block 100"))
+	c := node.NewClient()
+	node.SetFinalizedHead(100, hash)
+	require.Eventually(t, func() bool {
+		head, ok := c.FinalizedHead()
+		return ok && head.Number == 100
+	}, 5*time.Second, 10*time.Millisecond)
+
+	before := node.Calls()
+	nonce := types.NewUCompactFromUInt(1)
+	_, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, &sigtools.SigningContext{Nonce: &nonce})
+	require.NoError(t, err)
+	require.Equal(t, before, node.Calls(), "signing with a nonce should not need any RPC")
+
+	opts, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, nil)
+	require.NoError(t, err)
+	require.Equal(t, before+1, node.Calls(), "signing without a nonce should only look up the nonce")
+
+	vals := signedFields(t, opts)
+	require.Equal(t, types.NewUCompactFromUInt(4), vals[extrinsic.NonceSignedField])
+	require.Equal(t, hash, vals[extrinsic.BlockHashSignedField])
+	require.Equal(t, c.RuntimeVersion().SpecVersion, vals[extrinsic.SpecVersionSignedField])
+}
+
+func TestCreateSigningOptionsOverHTTP(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	node.Handle("system_accountNextIndex", func([]json.RawMessage) (any, error) {
+		return 4, nil
+	})
+	hash := types.NewHash([]byte("block 100"))
+	node.SetFinalizedHead(100, hash)
+	c, err := client.NewClient(node.HTTPURL())
+	require.NoError(t, err)
+	defer c.Close()
+
+	before := node.Calls()
+	opts, err := sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, nil)
+	require.NoError(t, err)
+	require.Equal(t, hash, signedFields(t, opts)[extrinsic.BlockHashSignedField])
+	require.Equal(t, before+3, node.Calls(), "the first signature looks up the finalized head and the nonce")
+
+	// the head is kept for the era period
+	for range 3 {
+		before = node.Calls()
+		opts, err = sigtools.CreateSigningOptions(c, signature.TestKeyringPairAlice, nil)
+		require.NoError(t, err)
+		require.Equal(t, before+1, node.Calls(), "later signatures only look up the nonce")
+		require.Equal(t, hash, signedFields(t, opts)[extrinsic.BlockHashSignedField])
+	}
+}
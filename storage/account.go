@@ -1,7 +1,7 @@
 package storage
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/subtrahend-labs/gobt/client"
@@ -20,22 +20,28 @@ type AccountInfo struct {
 	}
 }
 
-func GetAccountInfo(c *client.Client, accountID []byte, block *types.Hash) (*AccountInfo, error) {
-	meta, err := c.Api.RPC.State.GetMetadataLatest()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get metadata: %v", err)
-	}
+func GetAccountInfo(c *client.Client, accountID []byte, block *client.Block) (*AccountInfo, error) {
+	return GetAccountInfoContext(context.Background(), c, accountID, block)
+}
 
-	storageKey, err := types.CreateStorageKey(meta, "System", "Account", accountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create storage key: %v", err)
-	}
+func GetAccountInfoContext(ctx context.Context, c *client.Client, accountID []byte, block *client.Block) (*AccountInfo, error) {
+	return Query[AccountInfo](ctx, c, "System", "Account", block, accountID)
+}
 
-	var res AccountInfo
-	err = getStorageOptionalBlock(c, storageKey, &res, block)
-	if err != nil {
-		return nil, err
-	}
+// GetAccountInfos reads the accounts of many coldkeys at once. Accounts that
+// do not exist come back empty, as they do from GetAccountInfo.
+func GetAccountInfos(c *client.Client, accountIDs []types.AccountID, block *client.Block) ([]Result[AccountInfo], error) {
+	return GetAccountInfosContext(context.Background(), c, accountIDs, block)
+}
 
-	return &res, nil
+func GetAccountInfosContext(ctx context.Context, c *client.Client, accountIDs []types.AccountID, block *client.Block) ([]Result[AccountInfo], error) {
+	keys := make([]types.StorageKey, len(accountIDs))
+	for i, acc := range accountIDs {
+		key, err := Key(c, "System", "Account", acc.ToBytes())
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return QueryBatch[AccountInfo](ctx, c, block, keys)
 }
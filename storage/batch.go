@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+// BatchChunkSize is the most keys sent in one state_queryStorageAt request.
+// Nodes cap the size of a request, so larger batches are split.
+const BatchChunkSize = 256
+
+// Result is the outcome of reading one key of a batch
+type Result[T any] struct {
+	Key   types.StorageKey
+	Value *T
+	// Err is set when the value could not be decoded, or is ErrNotFound
+	// when nothing is stored under Key and the item has no default.
+	Err error
+}
+
+// Key builds the storage key of pallet.item under keys, checked against the
+// metadata like Query does.
+func Key(c *client.Client, pallet, item string, keys ...[]byte) (types.StorageKey, error) {
+	e, err := lookupEntry(c.Metadata(), pallet, item)
+	if err != nil {
+		return nil, err
+	}
+	return e.storageKey(keys)
+}
+
+// QueryBatch reads many storage keys with as few round trips as possible and
+// decodes every value into T. Results are in the order of keys. Only a failed
+// request fails the whole batch; missing and undecodable values are reported
+// per key, with missing values of items that have a default decoded from it
+// as Query does. block is optional; without it every chunk is read at the
+// same best block.
+func QueryBatch[T any](ctx context.Context, c *client.Client, block *client.Block, keys []types.StorageKey) ([]Result[T], error) {
+	hash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil && len(keys) > BatchChunkSize {
+		best, err := bestBlock(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		hash = &best
+	}
+
+	meta := c.Metadata()
+	entries := map[string]*entry{}
+	res := make([]Result[T], len(keys))
+	for start := 0; start < len(keys); start += BatchChunkSize {
+		end := min(start+BatchChunkSize, len(keys))
+		hexKeys := make([]string, 0, end-start)
+		for _, key := range keys[start:end] {
+			hexKeys = append(hexKeys, key.Hex())
+		}
+
+		values, err := queryStorageAt(ctx, c, hash, hexKeys)
+		if err != nil {
+			return nil, err
+		}
+		for i, key := range keys[start:end] {
+			res[start+i].Key = key
+			bz := values[key.Hex()]
+			var v T
+			if len(bz) > 0 {
+				if err := codec.Decode(bz, &v); err != nil {
+					res[start+i].Err = fmt.Errorf("failed to decode storage %s: %w", key.Hex(), err)
+					continue
+				}
+				res[start+i].Value = &v
+				continue
+			}
+
+			// only missing values need the item, for its default
+			prefix := string(key[:min(32, len(key))])
+			e, ok := entries[prefix]
+			if !ok {
+				if e, err = lookupEntryByKey(meta, key); err != nil {
+					res[start+i].Err = err
+					continue
+				}
+				entries[prefix] = e
+			}
+			if err := e.decode(nil, &v); err != nil {
+				res[start+i].Err = err
+				continue
+			}
+			res[start+i].Value = &v
+		}
+	}
+	return res, nil
+}
+
+// queryStorageAt reads hexKeys at block and returns the values found by key.
+// Keys with no value are left out.
+func queryStorageAt(ctx context.Context, c *client.Client, block *types.Hash, hexKeys []string) (map[string][]byte, error) {
+	var sets []types.StorageChangeSet
+	err := c.CallAt(ctx, &sets, "state_queryStorageAt", block, hexKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query storage: %w", err)
+	}
+
+	values := make(map[string][]byte, len(hexKeys))
+	for _, set := range sets {
+		for _, change := range set.Changes {
+			if change.HasStorageData && len(change.StorageData) > 0 {
+				values[change.StorageKey.Hex()] = change.StorageData
+			}
+		}
+	}
+	return values, nil
+}
+
+// bestBlock returns the hash of the best block, to pin reads spanning
+// several requests to one state.
+func bestBlock(ctx context.Context, c *client.Client) (types.Hash, error) {
+	var hash types.Hash
+	if err := c.Api.Client.CallContext(ctx, &hash, "chain_getBlockHash"); err != nil {
+		return types.Hash{}, fmt.Errorf("failed to get block hash: %w", err)
+	}
+	return hash, nil
+}
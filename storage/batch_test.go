@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestGetAccountInfos(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	accounts := make([]types.AccountID, storage.BatchChunkSize+44)
+	for i := range accounts {
+		accounts[i][0], accounts[i][1] = byte(i), byte(i>>8)
+		key, err := storage.Key(c, "System", "Account", accounts[i].ToBytes())
+		require.NoError(t, err)
+		switch {
+		case i%10 == 0:
+			// no account
+		case i == 7:
+			node.SetStorage(key, types.U8(1))
+		default:
+			node.SetStorage(key, storage.AccountInfo{Nonce: types.U32(i)})
+		}
+	}
+
+	res, err := storage.GetAccountInfos(c, accounts, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, node.MethodCalls("state_queryStorageAt"))
+
+	require.Len(t, res, len(accounts))
+	for i, r := range res {
+		switch {
+		case i%10 == 0:
+			require.NoError(t, r.Err, i)
+			require.Zero(t, r.Value.Nonce, i)
+		case i == 7:
+			require.Error(t, r.Err)
+		default:
+			require.NoError(t, r.Err, i)
+			require.EqualValues(t, i, r.Value.Nonce)
+		}
+	}
+}
+
+func TestQueryBatchMissing(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	number, err := storage.Key(c, "System", "Number")
+	require.NoError(t, err)
+	count, err := storage.Key(c, "System", "ExtrinsicCount")
+	require.NoError(t, err)
+	node.SetStorage(count, types.U32(4))
+	unknown := types.StorageKey(make([]byte, 32))
+
+	res, err := storage.QueryBatch[types.U32](context.Background(), c, nil, []types.StorageKey{number, count, unknown})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, *res[0].Value)
+	require.EqualValues(t, 4, *res[1].Value)
+	require.ErrorIs(t, res[2].Err, storage.ErrUnknownItem)
+
+	node.SetStorage(count, nil)
+	res, err = storage.QueryBatch[types.U32](context.Background(), c, nil, []types.StorageKey{count})
+	require.NoError(t, err)
+	require.Nil(t, res[0].Value)
+	require.ErrorIs(t, res[0].Err, storage.ErrNotFound)
+}
@@ -0,0 +1,418 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+// Blocks between epochs of a subnet
+func GetTempo(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetTempoContext(context.Background(), c, netuid, block)
+}
+
+func GetTempoContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "Tempo", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Consensus majority ratio of a subnet, normalized to u16 max
+func GetKappa(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetKappaContext(context.Background(), c, netuid, block)
+}
+
+func GetKappaContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "Kappa", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Rho of a subnet's incentive sigmoid
+func GetRho(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetRhoContext(context.Background(), c, netuid, block)
+}
+
+func GetRhoContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "Rho", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Blocks a new neuron cannot be deregistered for
+func GetImmunityPeriod(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetImmunityPeriodContext(context.Background(), c, netuid, block)
+}
+
+func GetImmunityPeriodContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "ImmunityPeriod", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Fewest weights a validator has to set
+func GetMinAllowedWeights(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetMinAllowedWeightsContext(context.Background(), c, netuid, block)
+}
+
+func GetMinAllowedWeightsContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "MinAllowedWeights", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Largest weight a validator can give one uid, normalized to u16 max
+func GetMaxWeightsLimit(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetMaxWeightsLimitContext(context.Background(), c, netuid, block)
+}
+
+func GetMaxWeightsLimitContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "MaxWeightsLimit", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Version weights have to be set with
+func GetWeightsVersionKey(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetWeightsVersionKeyContext(context.Background(), c, netuid, block)
+}
+
+func GetWeightsVersionKeyContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "WeightsVersionKey", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Blocks between weight updates of a validator
+func GetWeightsSetRateLimit(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetWeightsSetRateLimitContext(context.Background(), c, netuid, block)
+}
+
+func GetWeightsSetRateLimitContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "WeightsSetRateLimit", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Blocks after which a validator that did not set weights is inactive
+func GetActivityCutoff(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetActivityCutoffContext(context.Background(), c, netuid, block)
+}
+
+func GetActivityCutoffContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "ActivityCutoff", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Size of a subnet
+func GetMaxAllowedUids(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetMaxAllowedUidsContext(context.Background(), c, netuid, block)
+}
+
+func GetMaxAllowedUidsContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "MaxAllowedUids", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Most validator permits of a subnet
+func GetMaxAllowedValidators(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetMaxAllowedValidatorsContext(context.Background(), c, netuid, block)
+}
+
+func GetMaxAllowedValidatorsContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "MaxAllowedValidators", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Current registration cost of a subnet, in rao
+func GetBurn(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetBurnContext(context.Background(), c, netuid, block)
+}
+
+func GetBurnContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "Burn", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Lowest registration burn, in rao
+func GetMinBurn(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetMinBurnContext(context.Background(), c, netuid, block)
+}
+
+func GetMinBurnContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "MinBurn", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Highest registration burn, in rao
+func GetMaxBurn(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetMaxBurnContext(context.Background(), c, netuid, block)
+}
+
+func GetMaxBurnContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "MaxBurn", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Current proof of work registration difficulty
+func GetDifficulty(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetDifficultyContext(context.Background(), c, netuid, block)
+}
+
+func GetDifficultyContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "Difficulty", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Lowest proof of work registration difficulty
+func GetMinDifficulty(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetMinDifficultyContext(context.Background(), c, netuid, block)
+}
+
+func GetMinDifficultyContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "MinDifficulty", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Highest proof of work registration difficulty
+func GetMaxDifficulty(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetMaxDifficultyContext(context.Background(), c, netuid, block)
+}
+
+func GetMaxDifficultyContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "MaxDifficulty", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Blocks between axon or prometheus updates
+func GetServingRateLimit(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetServingRateLimitContext(context.Background(), c, netuid, block)
+}
+
+func GetServingRateLimitContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "ServingRateLimit", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Blocks between burn and difficulty adjustments
+func GetAdjustmentInterval(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetAdjustmentIntervalContext(context.Background(), c, netuid, block)
+}
+
+func GetAdjustmentIntervalContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "AdjustmentInterval", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Smoothing of burn and difficulty adjustments
+func GetAdjustmentAlpha(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetAdjustmentAlphaContext(context.Background(), c, netuid, block)
+}
+
+func GetAdjustmentAlphaContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "AdjustmentAlpha", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Registrations per adjustment interval the burn and difficulty aim for
+func GetTargetRegistrationsPerInterval(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetTargetRegistrationsPerIntervalContext(context.Background(), c, netuid, block)
+}
+
+func GetTargetRegistrationsPerIntervalContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "TargetRegistrationsPerInterval", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Most registrations allowed in one block
+func GetMaxRegistrationsPerBlock(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetMaxRegistrationsPerBlockContext(context.Background(), c, netuid, block)
+}
+
+func GetMaxRegistrationsPerBlockContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "MaxRegistrationsPerBlock", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Whether burned registration is open
+func GetNetworkRegistrationAllowed(c *client.Client, netuid types.U16, block *client.Block) (*types.Bool, error) {
+	return GetNetworkRegistrationAllowedContext(context.Background(), c, netuid, block)
+}
+
+func GetNetworkRegistrationAllowedContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.Bool, error) {
+	return Query[types.Bool](ctx, c, "SubtensorModule", "NetworkRegistrationAllowed", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Whether proof of work registration is open
+func GetNetworkPowRegistrationAllowed(c *client.Client, netuid types.U16, block *client.Block) (*types.Bool, error) {
+	return GetNetworkPowRegistrationAllowedContext(context.Background(), c, netuid, block)
+}
+
+func GetNetworkPowRegistrationAllowedContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.Bool, error) {
+	return Query[types.Bool](ctx, c, "SubtensorModule", "NetworkPowRegistrationAllowed", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Moving average of bonds, normalized to 1e6
+func GetBondsMovingAverage(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetBondsMovingAverageContext(context.Background(), c, netuid, block)
+}
+
+func GetBondsMovingAverageContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "BondsMovingAverage", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Penalty on bonds of out of consensus weights, normalized to u16 max
+func GetBondsPenalty(c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return GetBondsPenaltyContext(context.Background(), c, netuid, block)
+}
+
+func GetBondsPenaltyContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "BondsPenalty", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Blocks for the moving price of a subnet to halve its distance to the spot price
+func GetEMAPriceHalvingBlocks(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetEMAPriceHalvingBlocksContext(context.Background(), c, netuid, block)
+}
+
+func GetEMAPriceHalvingBlocksContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "EMAPriceHalvingBlocks", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Hotkey of a subnet's owner
+func GetSubnetOwnerHotkey(c *client.Client, netuid types.U16, block *client.Block) (*types.AccountID, error) {
+	return GetSubnetOwnerHotkeyContext(context.Background(), c, netuid, block)
+}
+
+func GetSubnetOwnerHotkeyContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.AccountID, error) {
+	return Query[types.AccountID](ctx, c, "SubtensorModule", "SubnetOwnerHotkey", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Blocks between subnet registrations
+func GetNetworkRateLimit(c *client.Client, block *client.Block) (*types.U64, error) {
+	return GetNetworkRateLimitContext(context.Background(), c, block)
+}
+
+func GetNetworkRateLimitContext(ctx context.Context, c *client.Client, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "NetworkRateLimit", block)
+}
+
+// Blocks between transactions of a hotkey
+func GetTxRateLimit(c *client.Client, block *client.Block) (*types.U64, error) {
+	return GetTxRateLimitContext(context.Background(), c, block)
+}
+
+func GetTxRateLimitContext(ctx context.Context, c *client.Client, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "TxRateLimit", block)
+}
+
+// Highest delegate take, normalized to u16 max
+func GetMaxDelegateTake(c *client.Client, block *client.Block) (*types.U16, error) {
+	return GetMaxDelegateTakeContext(context.Background(), c, block)
+}
+
+func GetMaxDelegateTakeContext(ctx context.Context, c *client.Client, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "MaxDelegateTake", block)
+}
+
+// Part of emissions going to subnet owners, normalized to u16 max
+func GetSubnetOwnerCut(c *client.Client, block *client.Block) (*types.U16, error) {
+	return GetSubnetOwnerCutContext(context.Background(), c, block)
+}
+
+func GetSubnetOwnerCutContext(ctx context.Context, c *client.Client, block *client.Block) (*types.U16, error) {
+	return Query[types.U16](ctx, c, "SubtensorModule", "SubnetOwnerCut", block)
+}
+
+// Smoothing factor of subnet moving prices
+func GetSubnetMovingAlpha(c *client.Client, block *client.Block) (*typetools.I96F32, error) {
+	return GetSubnetMovingAlphaContext(context.Background(), c, block)
+}
+
+func GetSubnetMovingAlphaContext(ctx context.Context, c *client.Client, block *client.Block) (*typetools.I96F32, error) {
+	return Query[typetools.I96F32](ctx, c, "SubtensorModule", "SubnetMovingAlpha", block)
+}
+
+// HyperparamsSnapshot holds the hyperparameters of a subnet at one block, as
+// stored by SubtensorModule and set through AdminUtils. Fields are named after
+// their storage items. runtime.SubnetHyperparams is the runtime API's view of
+// them.
+type HyperparamsSnapshot struct {
+	Netuid types.U16
+
+	Tempo                          types.U16
+	Kappa                          types.U16
+	Rho                            types.U16
+	ImmunityPeriod                 types.U16
+	MinAllowedWeights              types.U16
+	MaxWeightsLimit                types.U16
+	WeightsVersionKey              types.U64
+	WeightsSetRateLimit            types.U64
+	ActivityCutoff                 types.U16
+	MaxAllowedUids                 types.U16
+	MaxAllowedValidators           types.U16
+	Burn                           types.U64
+	MinBurn                        types.U64
+	MaxBurn                        types.U64
+	Difficulty                     types.U64
+	MinDifficulty                  types.U64
+	MaxDifficulty                  types.U64
+	ServingRateLimit               types.U64
+	AdjustmentInterval             types.U16
+	AdjustmentAlpha                types.U64
+	TargetRegistrationsPerInterval types.U16
+	MaxRegistrationsPerBlock       types.U16
+	NetworkRegistrationAllowed     types.Bool
+	NetworkPowRegistrationAllowed  types.Bool
+	BondsMovingAverage             types.U64
+	BondsPenalty                   types.U16
+	EMAPriceHalvingBlocks          types.U64
+	SubnetOwnerHotkey              types.AccountID
+}
+
+// GetSubnetHyperparams reads every hyperparameter of a subnet in one request,
+// so they all come from the same block. block is optional, nil reads the
+// best block.
+func GetSubnetHyperparams(c *client.Client, netuid types.U16, block *client.Block) (*HyperparamsSnapshot, error) {
+	return GetSubnetHyperparamsContext(context.Background(), c, netuid, block)
+}
+
+func GetSubnetHyperparamsContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*HyperparamsSnapshot, error) {
+	p := HyperparamsSnapshot{Netuid: netuid}
+	fields := []struct {
+		item  string
+		value any
+	}{
+		{"Tempo", &p.Tempo},
+		{"Kappa", &p.Kappa},
+		{"Rho", &p.Rho},
+		{"ImmunityPeriod", &p.ImmunityPeriod},
+		{"MinAllowedWeights", &p.MinAllowedWeights},
+		{"MaxWeightsLimit", &p.MaxWeightsLimit},
+		{"WeightsVersionKey", &p.WeightsVersionKey},
+		{"WeightsSetRateLimit", &p.WeightsSetRateLimit},
+		{"ActivityCutoff", &p.ActivityCutoff},
+		{"MaxAllowedUids", &p.MaxAllowedUids},
+		{"MaxAllowedValidators", &p.MaxAllowedValidators},
+		{"Burn", &p.Burn},
+		{"MinBurn", &p.MinBurn},
+		{"MaxBurn", &p.MaxBurn},
+		{"Difficulty", &p.Difficulty},
+		{"MinDifficulty", &p.MinDifficulty},
+		{"MaxDifficulty", &p.MaxDifficulty},
+		{"ServingRateLimit", &p.ServingRateLimit},
+		{"AdjustmentInterval", &p.AdjustmentInterval},
+		{"AdjustmentAlpha", &p.AdjustmentAlpha},
+		{"TargetRegistrationsPerInterval", &p.TargetRegistrationsPerInterval},
+		{"MaxRegistrationsPerBlock", &p.MaxRegistrationsPerBlock},
+		{"NetworkRegistrationAllowed", &p.NetworkRegistrationAllowed},
+		{"NetworkPowRegistrationAllowed", &p.NetworkPowRegistrationAllowed},
+		{"BondsMovingAverage", &p.BondsMovingAverage},
+		{"BondsPenalty", &p.BondsPenalty},
+		{"EMAPriceHalvingBlocks", &p.EMAPriceHalvingBlocks},
+		{"SubnetOwnerHotkey", &p.SubnetOwnerHotkey},
+	}
+
+	meta := c.Metadata()
+	entries := make([]*entry, len(fields))
+	hexKeys := make([]string, len(fields))
+	for i, f := range fields {
+		e, err := lookupEntry(meta, "SubtensorModule", f.item)
+		if err != nil {
+			return nil, err
+		}
+		key, err := e.storageKey([][]byte{typetools.Uint16ToBytes(uint16(netuid))})
+		if err != nil {
+			return nil, err
+		}
+		entries[i], hexKeys[i] = e, key.Hex()
+	}
+
+	hash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	values, err := queryStorageAt(ctx, c, hash, hexKeys)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range fields {
+		if err := entries[i].decode(values[hexKeys[i]], f.value); err != nil {
+			return nil, fmt.Errorf("subnet %d: %w", netuid, err)
+		}
+	}
+	return &p, nil
+}
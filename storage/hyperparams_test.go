@@ -0,0 +1,58 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+func TestHyperparamGetters(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	node.SetStorage(subtensorKey(t, c, "Tempo", typetools.Uint16ToBytes(1)), types.U16(360))
+	node.SetStorage(subtensorKey(t, c, "TxRateLimit"), types.U64(5))
+
+	tempo, err := storage.GetTempo(c, 1, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 360, *tempo)
+
+	tempo, err = storage.GetTempo(c, 2, nil)
+	require.NoError(t, err)
+	require.Zero(t, *tempo)
+
+	limit, err := storage.GetTxRateLimit(c, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, *limit)
+}
+
+func TestGetSubnetHyperparams(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	netuid := typetools.Uint16ToBytes(3)
+	owner := account(9)
+
+	node.SetStorage(subtensorKey(t, c, "Tempo", netuid), types.U16(99))
+	node.SetStorage(subtensorKey(t, c, "Kappa", netuid), types.U16(32767))
+	node.SetStorage(subtensorKey(t, c, "Burn", netuid), types.U64(1e9))
+	node.SetStorage(subtensorKey(t, c, "NetworkRegistrationAllowed", netuid), types.NewBool(true))
+	node.SetStorage(subtensorKey(t, c, "SubnetOwnerHotkey", netuid), owner)
+	// another subnet
+	node.SetStorage(subtensorKey(t, c, "Rho", typetools.Uint16ToBytes(4)), types.U16(10))
+
+	p, err := storage.GetSubnetHyperparams(c, 3, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, node.MethodCalls("state_queryStorageAt"))
+	require.EqualValues(t, 3, p.Netuid)
+	require.EqualValues(t, 99, p.Tempo)
+	require.EqualValues(t, 32767, p.Kappa)
+	require.EqualValues(t, 1e9, p.Burn)
+	require.True(t, bool(p.NetworkRegistrationAllowed))
+	require.Equal(t, owner, p.SubnetOwnerHotkey)
+	require.Zero(t, p.Rho)
+	require.False(t, bool(p.NetworkPowRegistrationAllowed))
+}
@@ -0,0 +1,380 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+// DefaultPageSize is how many keys an Iterator asks for at a time
+const DefaultPageSize = 100
+
+// Entry is one value of a storage map
+type Entry[T any] struct {
+	// Key is the full storage key of the value
+	Key types.StorageKey
+	// Keys are the SCALE encoded map keys the value is stored under, in
+	// order. Keys hashed without their plain value (Blake2_128, Twox128, ...)
+	// cannot be recovered and are nil.
+	Keys  [][]byte
+	Value T
+}
+
+// DecodeKey decodes the i-th map key into target
+func (e Entry[T]) DecodeKey(i int, target any) error {
+	if i >= len(e.Keys) || e.Keys[i] == nil {
+		return fmt.Errorf("key %d is not available", i)
+	}
+	if err := codec.Decode(e.Keys[i], target); err != nil {
+		return fmt.Errorf("failed to decode key %d: %w", i, err)
+	}
+	return nil
+}
+
+type IterOption func(*iterConfig)
+
+type iterConfig struct {
+	pageSize uint32
+	start    types.StorageKey
+	block    *client.Block
+	prefix   [][]byte
+}
+
+// WithPageSize sets how many entries are fetched per round trip
+func WithPageSize(n uint32) IterOption {
+	return func(cfg *iterConfig) {
+		cfg.pageSize = n
+	}
+}
+
+// WithStartKey resumes iteration after key, usually the LastKey of an
+// earlier Iterator.
+func WithStartKey(key types.StorageKey) IterOption {
+	return func(cfg *iterConfig) {
+		cfg.start = key
+	}
+}
+
+// AtBlock reads the map as of block. Without it the iterator pins the best
+// block when it fetches its first page, so every page comes from the same
+// state.
+func AtBlock(block *client.Block) IterOption {
+	return func(cfg *iterConfig) {
+		cfg.block = block
+	}
+}
+
+// WithPrefix only visits entries stored under the given leading keys, for
+// example a netuid of a double map keyed by netuid and hotkey.
+func WithPrefix(keys ...[]byte) IterOption {
+	return func(cfg *iterConfig) {
+		cfg.prefix = keys
+	}
+}
+
+// Iterator walks the entries of a storage map in key order, one page at a
+// time, using state_getKeysPaged and state_queryStorageAt.
+//
+//	it, err := storage.Iterate[types.U16](c, "SubtensorModule", "Uids", storage.WithPrefix(netuid))
+//	for it.Next(ctx) {
+//		e := it.Entry()
+//	}
+//	err = it.Err()
+type Iterator[T any] struct {
+	c        *client.Client
+	e        *entry
+	keyTypes []int64
+	prefix   types.StorageKey
+	pageSize uint32
+	at       *client.Block
+	block    *types.Hash
+
+	page    []Entry[T]
+	pos     int
+	last    types.StorageKey
+	done    bool
+	err     error
+	current Entry[T]
+}
+
+// Iterate returns an iterator over the map pallet.item decoding values into
+// T. The item and prefix keys are checked against the metadata right away,
+// nothing is fetched until Next is called.
+func Iterate[T any](c *client.Client, pallet, item string, opts ...IterOption) (*Iterator[T], error) {
+	cfg := iterConfig{pageSize: DefaultPageSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.pageSize == 0 {
+		return nil, fmt.Errorf("page size must be positive")
+	}
+
+	e, err := lookupEntry(c.Metadata(), pallet, item)
+	if err != nil {
+		return nil, err
+	}
+	if !e.Type.IsMap {
+		return nil, fmt.Errorf("%s is not a map", e.name())
+	}
+	keyTypes, err := e.keyTypes()
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := e.prefix(cfg.prefix)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.start != nil && !bytes.HasPrefix(cfg.start, prefix) {
+		return nil, fmt.Errorf("%w: start key is outside %s", ErrInvalidKey, e.name())
+	}
+
+	return &Iterator[T]{
+		c:        c,
+		e:        e,
+		keyTypes: keyTypes,
+		prefix:   prefix,
+		pageSize: cfg.pageSize,
+		at:       cfg.block,
+		last:     cfg.start,
+	}, nil
+}
+
+// Next advances to the next entry, fetching a new page when needed. It
+// returns false when the map is exhausted or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos == len(it.page) {
+		if it.done {
+			return false
+		}
+		if it.err = it.fetch(ctx); it.err != nil || len(it.page) == 0 {
+			return false
+		}
+	}
+	it.current = it.page[it.pos]
+	it.pos++
+	it.last = it.current.Key
+	return true
+}
+
+// Entry returns the entry Next moved to
+func (it *Iterator[T]) Entry() Entry[T] {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// LastKey returns the key of the last entry returned by Next. Pass it to
+// WithStartKey, together with the same block, to resume later:
+//
+//	storage.WithStartKey(it.LastKey()), storage.AtBlock(client.AtHash(*it.Block()))
+func (it *Iterator[T]) LastKey() types.StorageKey {
+	return it.last
+}
+
+// Block returns the block the map is read at, nil before the first page
+// when no block was given.
+func (it *Iterator[T]) Block() *types.Hash {
+	return it.block
+}
+
+// All drains the iterator
+func (it *Iterator[T]) All(ctx context.Context) ([]Entry[T], error) {
+	var res []Entry[T]
+	for it.Next(ctx) {
+		res = append(res, it.Entry())
+	}
+	return res, it.Err()
+}
+
+func (it *Iterator[T]) fetch(ctx context.Context) error {
+	if it.block == nil {
+		hash, err := it.c.BlockHash(ctx, it.at)
+		if err != nil {
+			return err
+		}
+		if hash == nil {
+			best, err := bestBlock(ctx, it.c)
+			if err != nil {
+				return err
+			}
+			hash = &best
+		}
+		it.block = hash
+	}
+
+	var start any
+	if it.last != nil {
+		start = it.last.Hex()
+	}
+	var hexKeys []string
+	err := it.c.CallAt(ctx, &hexKeys, "state_getKeysPaged", it.block, it.prefix.Hex(), it.pageSize, start)
+	if err != nil {
+		return fmt.Errorf("failed to get storage keys: %w", err)
+	}
+	if uint32(len(hexKeys)) < it.pageSize {
+		it.done = true
+	}
+	it.page, it.pos = it.page[:0], 0
+	if len(hexKeys) == 0 {
+		return nil
+	}
+
+	values, err := queryStorageAt(ctx, it.c, it.block, hexKeys)
+	if err != nil {
+		return err
+	}
+
+	for _, hexKey := range hexKeys {
+		// Removed since the keys were listed; cannot happen at a fixed
+		// block but costs nothing to handle.
+		bz, ok := values[hexKey]
+		if !ok {
+			continue
+		}
+		key, err := codec.HexDecodeString(hexKey)
+		if err != nil {
+			return fmt.Errorf("failed to decode storage key: %w", err)
+		}
+		keys, err := it.e.splitKey(key, it.keyTypes)
+		if err != nil {
+			return err
+		}
+		en := Entry[T]{Key: key, Keys: keys}
+		if err := codec.Decode(bz, &en.Value); err != nil {
+			return fmt.Errorf("failed to decode storage %s: %w", hexKey, err)
+		}
+		it.page = append(it.page, en)
+	}
+	if len(it.page) == 0 && !it.done {
+		// every key of the page vanished, carry on after the last one
+		key, err := codec.HexDecodeString(hexKeys[len(hexKeys)-1])
+		if err != nil {
+			return fmt.Errorf("failed to decode storage key: %w", err)
+		}
+		it.last = key
+		return it.fetch(ctx)
+	}
+	return nil
+}
+
+// splitKey recovers the encoded map keys from a full storage key
+func (e *entry) splitKey(key types.StorageKey, keyTypes []int64) ([][]byte, error) {
+	rest := []byte(key)
+	if len(rest) < 32 {
+		return nil, fmt.Errorf("%w: %s is too short for %s", ErrInvalidKey, key.Hex(), e.name())
+	}
+	rest = rest[32:]
+
+	keys := make([][]byte, len(keyTypes))
+	for i, h := range e.Type.AsMap.Hashers {
+		n, concat, err := hasherPrefix(h)
+		if err != nil {
+			return nil, fmt.Errorf("%s key %d: %w", e.name(), i, err)
+		}
+		if len(rest) < n {
+			return nil, fmt.Errorf("%w: %s is too short for %s", ErrInvalidKey, key.Hex(), e.name())
+		}
+		rest = rest[n:]
+		if !concat {
+			continue
+		}
+
+		size := len(rest)
+		if i < len(keyTypes)-1 {
+			if size, err = encodedSize(e.meta, keyTypes[i], rest); err != nil {
+				return nil, fmt.Errorf("%s key %d: %w", e.name(), i, err)
+			}
+		}
+		if size > len(rest) {
+			return nil, fmt.Errorf("%w: %s is too short for %s", ErrInvalidKey, key.Hex(), e.name())
+		}
+		keys[i], rest = rest[:size], rest[size:]
+	}
+	return keys, nil
+}
+
+// encodedSize returns the size of the value of type id at the start of bz.
+// It covers fixed size types, strings and vectors of fixed size elements,
+// which is what map keys are in practice.
+func encodedSize(meta *types.Metadata, id int64, bz []byte) (int, error) {
+	if size, ok := fixedSize(meta, id); ok {
+		return size, nil
+	}
+
+	typ, ok := meta.AsMetadataV14.EfficientLookup[id]
+	if !ok {
+		return 0, fmt.Errorf("unknown type %d", id)
+	}
+	def := typ.Def
+	elem := 0
+	switch {
+	case def.IsPrimitive && def.Primitive.Si0TypeDefPrimitive == types.IsStr:
+		elem = 1
+	case def.IsSequence:
+		if elem, ok = fixedSize(meta, def.Sequence.Type.Int64()); !ok {
+			return 0, fmt.Errorf("vector of variable size type %d", def.Sequence.Type.Int64())
+		}
+	case def.IsCompact:
+		n, _, err := compactLen(bz)
+		return n, err
+	default:
+		return 0, fmt.Errorf("cannot size type %d", id)
+	}
+
+	n, length, err := compactLen(bz)
+	if err != nil {
+		return 0, err
+	}
+	// checked before multiplying, a hostile length could overflow the size
+	if elem > 0 && length > uint64(len(bz)-n)/uint64(elem) {
+		return 0, fmt.Errorf("%w: length %d is past the end of the key", ErrInvalidKey, length)
+	}
+	return n + int(length)*elem, nil
+}
+
+// compactLen reads a SCALE compact integer at the start of bz and returns its
+// encoded size and value.
+func compactLen(bz []byte) (int, uint64, error) {
+	if len(bz) == 0 {
+		return 0, 0, fmt.Errorf("missing compact integer")
+	}
+	var n int
+	switch bz[0] & 0b11 {
+	case 0b00:
+		n = 1
+	case 0b01:
+		n = 2
+	case 0b10:
+		n = 4
+	default:
+		n = int(bz[0]>>2) + 5
+	}
+	if len(bz) < n {
+		return 0, 0, fmt.Errorf("truncated compact integer")
+	}
+	if n > 9 {
+		return 0, 0, fmt.Errorf("compact integer too large")
+	}
+
+	var v uint64
+	if n > 4 {
+		for i := n - 1; i >= 1; i-- {
+			v = v<<8 | uint64(bz[i])
+		}
+		return n, v, nil
+	}
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | uint64(bz[i])
+	}
+	return n, v >> 2, nil
+}
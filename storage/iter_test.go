@@ -0,0 +1,119 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestIterateMapPages(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	ctx := context.Background()
+
+	want := map[types.U32]types.Hash{}
+	for i := types.U32(1); i <= 5; i++ {
+		key, err := types.CreateStorageKey(c.Metadata(), "System", "BlockHash", mustEncode(t, i))
+		require.NoError(t, err)
+		want[i] = types.NewHash([]byte{byte(i)})
+		node.SetStorage(key, want[i])
+	}
+	// another item must not leak into the iteration
+	other, err := types.CreateStorageKey(c.Metadata(), "System", "Number")
+	require.NoError(t, err)
+	node.SetStorage(other, types.U32(9))
+
+	it, err := storage.Iterate[types.Hash](c, "System", "BlockHash", storage.WithPageSize(2))
+	require.NoError(t, err)
+	got := map[types.U32]types.Hash{}
+	for it.Next(ctx) {
+		e := it.Entry()
+		var number types.U32
+		require.NoError(t, e.DecodeKey(0, &number))
+		got[number] = e.Value
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, want, got)
+	require.NotNil(t, it.Block())
+
+	// resume after the third entry
+	it, err = storage.Iterate[types.Hash](c, "System", "BlockHash", storage.WithPageSize(3))
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.True(t, it.Next(ctx))
+	}
+	rest, err := storage.Iterate[types.Hash](c, "System", "BlockHash", storage.WithStartKey(it.LastKey()), storage.AtBlock(client.AtHash(*it.Block())))
+	require.NoError(t, err)
+	entries, err := rest.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestIterateDoubleMapPrefix(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	alice := signature.TestKeyringPairAlice.PublicKey
+	bob := make([]byte, 32)
+	bob[0] = 1
+
+	for _, owner := range [][]byte{alice, bob} {
+		for i := byte(0); i < 3; i++ {
+			callHash := make([]byte, 32)
+			callHash[31] = i
+			key, err := types.CreateStorageKey(c.Metadata(), "Multisig", "Multisigs", owner, callHash)
+			require.NoError(t, err)
+			node.SetStorage(key, types.U32(i))
+		}
+	}
+
+	it, err := storage.Iterate[types.U32](c, "Multisig", "Multisigs", storage.WithPrefix(alice))
+	require.NoError(t, err)
+	entries, err := it.All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	for _, e := range entries {
+		require.Len(t, e.Keys, 2)
+		require.Equal(t, alice, e.Keys[0])
+		var callHash [32]byte
+		require.NoError(t, e.DecodeKey(1, &callHash))
+		require.EqualValues(t, e.Value, callHash[31])
+	}
+}
+
+func TestIterateChecksItem(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	_, err := storage.Iterate[types.U32](c, "System", "Number")
+	require.Error(t, err)
+	_, err = storage.Iterate[types.U32](c, "System", "Account", storage.WithPrefix(make([]byte, 32), make([]byte, 32)))
+	require.ErrorIs(t, err, storage.ErrKeyCount)
+	_, err = storage.Iterate[types.U32](c, "System", "Account", storage.WithStartKey(types.StorageKey{1, 2, 3}))
+	require.ErrorIs(t, err, storage.ErrInvalidKey)
+}
+
+func TestIterateOversizedKeyLength(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	name := append([]byte{4 << 2}, "apex"...)
+	key, err := types.CreateStorageKey(c.Metadata(), "SubtensorModule", "TestNamedStake", name, []byte{1, 0})
+	require.NoError(t, err)
+	node.SetStorage(key, types.U64(5))
+
+	// a compact length of 2^63, past what the key holds
+	bad := append(types.StorageKey{}, key[:48]...)
+	bad = append(bad, 0x13, 0, 0, 0, 0, 0, 0, 0, 0x80, 1, 0)
+	node.SetStorage(bad, types.U64(6))
+
+	it, err := storage.Iterate[types.U64](c, "SubtensorModule", "TestNamedStake")
+	require.NoError(t, err)
+	_, err = it.All(context.Background())
+	require.ErrorIs(t, err, storage.ErrInvalidKey)
+}
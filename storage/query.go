@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+var (
+	// ErrUnknownItem is returned for a pallet or storage item the runtime
+	// does not have.
+	ErrUnknownItem = errors.New("unknown storage item")
+	// ErrKeyCount is returned when the number of keys does not match the
+	// storage item.
+	ErrKeyCount = errors.New("wrong number of storage keys")
+	// ErrInvalidKey is returned when a key cannot be the SCALE encoding of
+	// the key type declared in the metadata.
+	ErrInvalidKey = errors.New("invalid storage key")
+	// ErrNotFound is returned when nothing is stored under the key of an
+	// item without a default value (an OptionQuery in the runtime).
+	ErrNotFound = errors.New("storage not found")
+)
+
+// Query reads the storage item pallet.item and decodes it into T. Keys are
+// SCALE encoded and checked against the item's metadata before the node is
+// asked. block is optional, nil reads the best block.
+//
+// When nothing is stored under the key, items with a default value (a
+// ValueQuery in the runtime) decode the default from the metadata, others
+// fail with ErrNotFound.
+func Query[T any](ctx context.Context, c *client.Client, pallet, item string, block *client.Block, keys ...[]byte) (*T, error) {
+	e, err := lookupEntry(c.Metadata(), pallet, item)
+	if err != nil {
+		return nil, err
+	}
+	key, err := e.storageKey(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := getStorageOptionalBlock(ctx, c, key, hash)
+	if err != nil {
+		return nil, err
+	}
+	var res T
+	if err := e.decode(bz, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// entry is the metadata of one storage item
+type entry struct {
+	meta   *types.Metadata
+	pallet string
+	// storage prefix of the pallet, hashed into keys in place of its name
+	storagePrefix string
+	item          string
+	types.StorageEntryMetadataV14
+}
+
+func lookupEntry(meta *types.Metadata, pallet, item string) (*entry, error) {
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("unsupported metadata version %d", meta.Version)
+	}
+	for _, p := range meta.AsMetadataV14.Pallets {
+		if string(p.Name) != pallet || !p.HasStorage {
+			continue
+		}
+		for _, it := range p.Storage.Items {
+			if string(it.Name) == item {
+				return &entry{meta: meta, pallet: pallet, storagePrefix: string(p.Storage.Prefix), item: item, StorageEntryMetadataV14: it}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s.%s", ErrUnknownItem, pallet, item)
+}
+
+// lookupEntryByKey finds the item a full storage key belongs to
+func lookupEntryByKey(meta *types.Metadata, key types.StorageKey) (*entry, error) {
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("unsupported metadata version %d", meta.Version)
+	}
+	if len(key) < 32 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownItem, key.Hex())
+	}
+	for _, p := range meta.AsMetadataV14.Pallets {
+		if !p.HasStorage || !bytes.Equal(key[:16], xxhash.New128([]byte(p.Storage.Prefix)).Sum(nil)) {
+			continue
+		}
+		for _, it := range p.Storage.Items {
+			if bytes.Equal(key[16:32], xxhash.New128([]byte(it.Name)).Sum(nil)) {
+				return &entry{meta: meta, pallet: string(p.Name), storagePrefix: string(p.Storage.Prefix), item: string(it.Name), StorageEntryMetadataV14: it}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownItem, key.Hex())
+}
+
+func (e *entry) name() string {
+	return e.pallet + "." + e.item
+}
+
+// decode decodes a raw value into res. An empty value stands for a missing
+// one, replaced by the item's default if it has one.
+func (e *entry) decode(bz []byte, res any) error {
+	if len(bz) == 0 {
+		if !e.Modifier.IsDefault {
+			return fmt.Errorf("%w: %s", ErrNotFound, e.name())
+		}
+		bz = e.Fallback
+	}
+	if err := codec.Decode(bz, res); err != nil {
+		return fmt.Errorf("failed to decode storage %s: %w", e.name(), err)
+	}
+	return nil
+}
+
+// keyTypes returns the type of every key of a map, nil for a plain item.
+func (e *entry) keyTypes() ([]int64, error) {
+	if !e.Type.IsMap {
+		return nil, nil
+	}
+	key := e.Type.AsMap.Key.Int64()
+	if len(e.Type.AsMap.Hashers) == 1 {
+		return []int64{key}, nil
+	}
+
+	typ, ok := e.meta.AsMetadataV14.EfficientLookup[key]
+	if !ok || !typ.Def.IsTuple || len(typ.Def.Tuple) != len(e.Type.AsMap.Hashers) {
+		return nil, fmt.Errorf("%s: key type does not match its %d hashers", e.name(), len(e.Type.AsMap.Hashers))
+	}
+	ids := make([]int64, len(typ.Def.Tuple))
+	for i, id := range typ.Def.Tuple {
+		ids[i] = id.Int64()
+	}
+	return ids, nil
+}
+
+// storageKey builds the key of the value stored under keys, which must
+// cover every key of the item.
+func (e *entry) storageKey(keys [][]byte) (types.StorageKey, error) {
+	keyTypes, err := e.keyTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != len(keyTypes) {
+		return nil, fmt.Errorf("%w: %s takes %d, got %d", ErrKeyCount, e.name(), len(keyTypes), len(keys))
+	}
+	if err := e.checkKeys(keys, keyTypes); err != nil {
+		return nil, err
+	}
+
+	return e.hashKeys(keys)
+}
+
+// prefix builds the key every value stored under the leading keys starts
+// with. With no keys it is the prefix of the whole item.
+func (e *entry) prefix(keys [][]byte) (types.StorageKey, error) {
+	keyTypes, err := e.keyTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > len(keyTypes) {
+		return nil, fmt.Errorf("%w: %s takes at most %d, got %d", ErrKeyCount, e.name(), len(keyTypes), len(keys))
+	}
+	if err := e.checkKeys(keys, keyTypes); err != nil {
+		return nil, err
+	}
+	return e.hashKeys(keys)
+}
+
+func (e *entry) hashKeys(keys [][]byte) (types.StorageKey, error) {
+	key := append(xxhash.New128([]byte(e.storagePrefix)).Sum(nil), xxhash.New128([]byte(e.item)).Sum(nil)...)
+	for i, k := range keys {
+		h, err := e.Type.AsMap.Hashers[i].HashFunc()
+		if err != nil {
+			return nil, fmt.Errorf("%s key %d: %w", e.name(), i, err)
+		}
+		h.Write(k)
+		key = h.Sum(key)
+	}
+	return key, nil
+}
+
+func (e *entry) checkKeys(keys [][]byte, keyTypes []int64) error {
+	for i, k := range keys {
+		if _, _, err := hasherPrefix(e.Type.AsMap.Hashers[i]); err != nil {
+			return fmt.Errorf("%s key %d: %w", e.name(), i, err)
+		}
+		size, fixed := fixedSize(e.meta, keyTypes[i])
+		if fixed && size != len(k) {
+			return fmt.Errorf("%w: %s key %d must be %d bytes, got %d", ErrInvalidKey, e.name(), i, size, len(k))
+		}
+		if !fixed && len(k) == 0 {
+			return fmt.Errorf("%w: %s key %d is empty", ErrInvalidKey, e.name(), i)
+		}
+	}
+	return nil
+}
+
+// hasherPrefix returns how many bytes the hasher puts in front of a key and
+// whether the key itself follows, which it does for the concat and identity
+// hashers.
+func hasherPrefix(h types.StorageHasherV10) (int, bool, error) {
+	switch {
+	case h.IsBlake2_128, h.IsTwox128:
+		return 16, false, nil
+	case h.IsBlake2_256, h.IsTwox256:
+		return 32, false, nil
+	case h.IsBlake2_128Concat:
+		return 16, true, nil
+	case h.IsTwox64Concat:
+		return 8, true, nil
+	case h.IsIdentity:
+		return 0, true, nil
+	default:
+		return 0, false, fmt.Errorf("unsupported hasher")
+	}
+}
+
+// fixedSize returns the encoded size of a type if every value of it has the
+// same size, such as integers, AccountId or tuples of them.
+func fixedSize(meta *types.Metadata, id int64) (int, bool) {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[id]
+	if !ok {
+		return 0, false
+	}
+
+	def := typ.Def
+	switch {
+	case def.IsPrimitive:
+		switch def.Primitive.Si0TypeDefPrimitive {
+		case types.IsBool, types.IsU8, types.IsI8:
+			return 1, true
+		case types.IsU16, types.IsI16:
+			return 2, true
+		case types.IsChar, types.IsU32, types.IsI32:
+			return 4, true
+		case types.IsU64, types.IsI64:
+			return 8, true
+		case types.IsU128, types.IsI128:
+			return 16, true
+		case types.IsU256, types.IsI256:
+			return 32, true
+		}
+	case def.IsArray:
+		size, ok := fixedSize(meta, def.Array.Type.Int64())
+		return size * int(def.Array.Len), ok
+	case def.IsTuple:
+		ids := make([]int64, len(def.Tuple))
+		for i, t := range def.Tuple {
+			ids[i] = t.Int64()
+		}
+		return fixedSizeAll(meta, ids)
+	case def.IsComposite:
+		ids := make([]int64, len(def.Composite.Fields))
+		for i, f := range def.Composite.Fields {
+			ids[i] = f.Type.Int64()
+		}
+		return fixedSizeAll(meta, ids)
+	}
+	return 0, false
+}
+
+func fixedSizeAll(meta *types.Metadata, ids []int64) (int, bool) {
+	total := 0
+	for _, id := range ids {
+		size, ok := fixedSize(meta, id)
+		if !ok {
+			return 0, false
+		}
+		total += size
+	}
+	return total, true
+}
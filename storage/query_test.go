@@ -0,0 +1,147 @@
+package storage_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestQueryPlain(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	node.Respond("state_getStorage", mustEncodeHex(t, types.U32(42)))
+	c := node.NewClient()
+
+	n, err := storage.Query[types.U32](context.Background(), c, "System", "Number", nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, *n)
+}
+
+func TestQueryAtBlock(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	hash := types.NewHash([]byte("block 7"))
+	node.SetFinalizedHead(7, hash)
+
+	node.Handle("state_getStorage", func(params []json.RawMessage) (any, error) {
+		var at string
+		if len(params) != 2 || json.Unmarshal(params[1], &at) != nil || at != hash.Hex() {
+			return nil, fmt.Errorf("storage read at the wrong block: %s", params)
+		}
+		return mustEncodeHex(t, types.U32(7)), nil
+	})
+
+	n, err := storage.Query[types.U32](context.Background(), c, "System", "Number", client.AtNumber(7))
+	require.NoError(t, err)
+	require.EqualValues(t, 7, *n)
+}
+
+func TestQueryMissing(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	ctx := context.Background()
+
+	// System.Account is a ValueQuery, missing accounts decode the default
+	info, err := storage.Query[storage.AccountInfo](ctx, c, "System", "Account", nil, signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+	require.Zero(t, info.Nonce)
+	require.Zero(t, info.Data.Free)
+
+	// System.ExtrinsicCount is an OptionQuery
+	_, err = storage.Query[types.U32](ctx, c, "System", "ExtrinsicCount", nil)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestQueryDoubleMap(t *testing.T) {
+	alice := signature.TestKeyringPairAlice.PublicKey
+	callHash := make([]byte, 32)
+
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	want, err := types.CreateStorageKey(c.Metadata(), "Multisig", "Multisigs", alice, callHash)
+	require.NoError(t, err)
+
+	node.Handle("state_getStorage", func(params []json.RawMessage) (any, error) {
+		var key string
+		if json.Unmarshal(params[0], &key) != nil || key != want.Hex() {
+			return nil, fmt.Errorf("unexpected storage key %s", params[0])
+		}
+		return mustEncodeHex(t, types.U32(7)), nil
+	})
+
+	v, err := storage.Query[types.U32](context.Background(), c, "Multisig", "Multisigs", nil, alice, callHash)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, *v)
+}
+
+func TestQueryChecksKeys(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	ctx := context.Background()
+	alice := signature.TestKeyringPairAlice.PublicKey
+
+	tests := []struct {
+		name   string
+		pallet string
+		item   string
+		keys   [][]byte
+		want   error
+	}{
+		{"missing key", "System", "Account", nil, storage.ErrKeyCount},
+		{"extra key", "System", "Account", [][]byte{alice, alice}, storage.ErrKeyCount},
+		{"key on plain item", "System", "Number", [][]byte{{1}}, storage.ErrKeyCount},
+		{"short account id", "System", "Account", [][]byte{alice[:31]}, storage.ErrInvalidKey},
+		{"wrong integer width", "System", "BlockHash", [][]byte{{1, 0}}, storage.ErrInvalidKey},
+		{"unknown item", "System", "Accounts", [][]byte{alice}, storage.ErrUnknownItem},
+		{"unknown pallet", "Nonexistent", "Alpha", nil, storage.ErrUnknownItem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := storage.Query[types.U32](ctx, c, tt.pallet, tt.item, nil, tt.keys...)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, tt.want), err.Error())
+		})
+	}
+}
+
+func TestQueryPalletPrefix(t *testing.T) {
+	// pallets are named in metadata apart from the prefix of their storage
+	meta := testutils.FakeMetadata()
+	for i, p := range meta.AsMetadataV14.Pallets {
+		if p.Name == "SubtensorModule" {
+			meta.AsMetadataV14.Pallets[i].Name = "Subtensor"
+		}
+	}
+	hex, err := codec.EncodeToHex(meta)
+	require.NoError(t, err)
+
+	node := testutils.NewFakeNode(t)
+	node.Respond("state_getMetadata", hex)
+	c := node.NewClient()
+
+	key, err := storage.Key(c, "Subtensor", "SubnetTAO", []byte{1, 0})
+	require.NoError(t, err)
+	want := append(xxhash.New128([]byte("SubtensorModule")).Sum(nil), xxhash.New128([]byte("SubnetTAO")).Sum(nil)...)
+	require.Equal(t, types.StorageKey(append(want, 1, 0)), key)
+
+	node.SetStorage(key, types.U64(7))
+	tao, err := storage.Query[types.U64](context.Background(), c, "Subtensor", "SubnetTAO", nil, []byte{1, 0})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, *tao)
+
+	// keys built by name are found again from the key alone
+	res, err := storage.QueryBatch[types.U64](context.Background(), c, nil, []types.StorageKey{key})
+	require.NoError(t, err)
+	require.NoError(t, res[0].Err)
+	require.EqualValues(t, 7, *res[0].Value)
+}
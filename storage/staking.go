@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+// Alpha shares of a coldkey staked to a hotkey on a subnet
+func GetAlpha(c *client.Client, hotkey, coldkey types.AccountID, netuid types.U16, block *client.Block) (*typetools.U64F64, error) {
+	return GetAlphaContext(context.Background(), c, hotkey, coldkey, netuid, block)
+}
+
+func GetAlphaContext(ctx context.Context, c *client.Client, hotkey, coldkey types.AccountID, netuid types.U16, block *client.Block) (*typetools.U64F64, error) {
+	return Query[typetools.U64F64](ctx, c, "SubtensorModule", "Alpha", block, hotkey.ToBytes(), coldkey.ToBytes(), typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Alpha staked to a hotkey on a subnet, by all coldkeys
+func GetTotalHotkeyAlpha(c *client.Client, hotkey types.AccountID, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetTotalHotkeyAlphaContext(context.Background(), c, hotkey, netuid, block)
+}
+
+func GetTotalHotkeyAlphaContext(ctx context.Context, c *client.Client, hotkey types.AccountID, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "TotalHotkeyAlpha", block, hotkey.ToBytes(), typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Alpha shares issued by a hotkey on a subnet
+func GetTotalHotkeyShares(c *client.Client, hotkey types.AccountID, netuid types.U16, block *client.Block) (*typetools.U64F64, error) {
+	return GetTotalHotkeySharesContext(context.Background(), c, hotkey, netuid, block)
+}
+
+func GetTotalHotkeySharesContext(ctx context.Context, c *client.Client, hotkey types.AccountID, netuid types.U16, block *client.Block) (*typetools.U64F64, error) {
+	return Query[typetools.U64F64](ctx, c, "SubtensorModule", "TotalHotkeyShares", block, hotkey.ToBytes(), typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// TAO in the subnet pool, in rao
+func GetSubnetTAO(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetSubnetTAOContext(context.Background(), c, netuid, block)
+}
+
+func GetSubnetTAOContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "SubnetTAO", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Alpha in the subnet pool
+func GetSubnetAlphaIn(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetSubnetAlphaInContext(context.Background(), c, netuid, block)
+}
+
+func GetSubnetAlphaInContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "SubnetAlphaIn", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Alpha outside the subnet pool, held as stake
+func GetSubnetAlphaOut(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetSubnetAlphaOutContext(context.Background(), c, netuid, block)
+}
+
+func GetSubnetAlphaOutContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "SubnetAlphaOut", block, typetools.Uint16ToBytes(uint16(netuid)))
+}
+
+// Hotkeys a coldkey has stake on
+func GetStakingHotkeys(c *client.Client, coldkey types.AccountID, block *client.Block) (*[]types.AccountID, error) {
+	return GetStakingHotkeysContext(context.Background(), c, coldkey, block)
+}
+
+func GetStakingHotkeysContext(ctx context.Context, c *client.Client, coldkey types.AccountID, block *client.Block) (*[]types.AccountID, error) {
+	return Query[[]types.AccountID](ctx, c, "SubtensorModule", "StakingHotkeys", block, coldkey.ToBytes())
+}
+
+// Hotkeys registered by a coldkey
+func GetOwnedHotkeys(c *client.Client, coldkey types.AccountID, block *client.Block) (*[]types.AccountID, error) {
+	return GetOwnedHotkeysContext(context.Background(), c, coldkey, block)
+}
+
+func GetOwnedHotkeysContext(ctx context.Context, c *client.Client, coldkey types.AccountID, block *client.Block) (*[]types.AccountID, error) {
+	return Query[[]types.AccountID](ctx, c, "SubtensorModule", "OwnedHotkeys", block, coldkey.ToBytes())
+}
+
+// Stake of a coldkey on one hotkey and subnet
+type Stake struct {
+	Hotkey types.AccountID
+	Netuid types.U16
+	Shares typetools.U64F64
+	// Alpha is the stake in the subnet's alpha, the coldkey's part of
+	// TotalHotkeyAlpha
+	Alpha types.U64
+}
+
+// GetStakesForColdkey returns the stake of a coldkey on every hotkey it
+// stakes to, per subnet, all read at the same block. Subnets with no shares
+// left are skipped. block is optional, nil reads the best block.
+func GetStakesForColdkey(c *client.Client, coldkey types.AccountID, block *client.Block) ([]Stake, error) {
+	return GetStakesForColdkeyContext(context.Background(), c, coldkey, block)
+}
+
+func GetStakesForColdkeyContext(ctx context.Context, c *client.Client, coldkey types.AccountID, block *client.Block) ([]Stake, error) {
+	hash, err := c.BlockHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil {
+		best, err := bestBlock(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		hash = &best
+	}
+	block = client.AtHash(*hash)
+
+	hotkeys, err := GetStakingHotkeysContext(ctx, c, coldkey, block)
+	if err != nil {
+		return nil, err
+	}
+
+	var stakes []Stake
+	for _, hotkey := range *hotkeys {
+		it, err := Iterate[typetools.U64F64](c, "SubtensorModule", "Alpha", AtBlock(block), WithPrefix(hotkey.ToBytes(), coldkey.ToBytes()))
+		if err != nil {
+			return nil, err
+		}
+		for it.Next(ctx) {
+			e := it.Entry()
+			if e.Value.Bits.Int == nil || e.Value.Bits.Sign() == 0 {
+				continue
+			}
+			var netuid types.U16
+			if err := e.DecodeKey(2, &netuid); err != nil {
+				return nil, err
+			}
+			stakes = append(stakes, Stake{Hotkey: hotkey, Netuid: netuid, Shares: e.Value})
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if len(stakes) == 0 {
+		return nil, nil
+	}
+
+	alphaKeys := make([]types.StorageKey, len(stakes))
+	sharesKeys := make([]types.StorageKey, len(stakes))
+	for i, s := range stakes {
+		netuid := typetools.Uint16ToBytes(uint16(s.Netuid))
+		if alphaKeys[i], err = Key(c, "SubtensorModule", "TotalHotkeyAlpha", s.Hotkey.ToBytes(), netuid); err != nil {
+			return nil, err
+		}
+		if sharesKeys[i], err = Key(c, "SubtensorModule", "TotalHotkeyShares", s.Hotkey.ToBytes(), netuid); err != nil {
+			return nil, err
+		}
+	}
+	totalAlpha, err := QueryBatch[types.U64](ctx, c, block, alphaKeys)
+	if err != nil {
+		return nil, err
+	}
+	totalShares, err := QueryBatch[typetools.U64F64](ctx, c, block, sharesKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stakes {
+		if totalAlpha[i].Err != nil {
+			return nil, totalAlpha[i].Err
+		}
+		if totalShares[i].Err != nil {
+			return nil, totalShares[i].Err
+		}
+		stakes[i].Alpha = stakeAlpha(stakes[i].Shares, *totalAlpha[i].Value, *totalShares[i].Value)
+	}
+	return stakes, nil
+}
+
+// stakeAlpha is the alpha owned by shares out of totalShares of a hotkey
+// holding totalAlpha.
+func stakeAlpha(shares typetools.U64F64, totalAlpha types.U64, totalShares typetools.U64F64) types.U64 {
+	if totalShares.Bits.Int == nil || totalShares.Bits.Sign() == 0 {
+		return 0
+	}
+	v := new(big.Int).Mul(shares.Bits.Int, new(big.Int).SetUint64(uint64(totalAlpha)))
+	v.Quo(v, totalShares.Bits.Int)
+	return types.U64(v.Uint64())
+}
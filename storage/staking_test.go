@@ -0,0 +1,101 @@
+package storage_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+func TestStakingAccessors(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	hotkey, coldkey := account(1), account(2)
+	netuid := typetools.Uint16ToBytes(3)
+
+	node.SetStorage(subtensorKey(t, c, "Alpha", hotkey[:], coldkey[:], netuid), fixed(5))
+	node.SetStorage(subtensorKey(t, c, "TotalHotkeyAlpha", hotkey[:], netuid), types.U64(100))
+	node.SetStorage(subtensorKey(t, c, "SubnetTAO", netuid), types.U64(7))
+	node.SetStorage(subtensorKey(t, c, "StakingHotkeys", coldkey[:]), []types.AccountID{hotkey})
+
+	alpha, err := storage.GetAlpha(c, hotkey, coldkey, 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, 5.0, alpha.Float64())
+
+	total, err := storage.GetTotalHotkeyAlpha(c, hotkey, 3, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, *total)
+
+	tao, err := storage.GetSubnetTAO(c, 3, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, *tao)
+
+	// defaults for what is not stored
+	alphaIn, err := storage.GetSubnetAlphaIn(c, 3, nil)
+	require.NoError(t, err)
+	require.Zero(t, *alphaIn)
+
+	hotkeys, err := storage.GetStakingHotkeys(c, coldkey, nil)
+	require.NoError(t, err)
+	require.Equal(t, []types.AccountID{hotkey}, *hotkeys)
+
+	owned, err := storage.GetOwnedHotkeys(c, coldkey, nil)
+	require.NoError(t, err)
+	require.Empty(t, *owned)
+}
+
+func TestGetStakesForColdkey(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	coldkey, other := account(1), account(2)
+	h1, h2 := account(3), account(4)
+
+	node.SetStorage(subtensorKey(t, c, "StakingHotkeys", coldkey[:]), []types.AccountID{h1, h2})
+	setStake := func(hotkey, coldkey types.AccountID, netuid uint16, shares uint64) {
+		node.SetStorage(subtensorKey(t, c, "Alpha", hotkey[:], coldkey[:], typetools.Uint16ToBytes(netuid)), fixed(shares))
+	}
+	setTotals := func(hotkey types.AccountID, netuid uint16, alpha, shares uint64) {
+		n := typetools.Uint16ToBytes(netuid)
+		node.SetStorage(subtensorKey(t, c, "TotalHotkeyAlpha", hotkey[:], n), types.U64(alpha))
+		node.SetStorage(subtensorKey(t, c, "TotalHotkeyShares", hotkey[:], n), fixed(shares))
+	}
+	setStake(h1, coldkey, 1, 10)
+	setStake(h1, coldkey, 2, 1)
+	setStake(h1, other, 1, 30)
+	setStake(h2, coldkey, 1, 0)
+	setTotals(h1, 1, 1000, 40)
+	setTotals(h1, 2, 50, 2)
+	setTotals(h2, 1, 500, 10)
+
+	stakes, err := storage.GetStakesForColdkey(c, coldkey, nil)
+	require.NoError(t, err)
+	require.Len(t, stakes, 2)
+	require.Equal(t, h1, stakes[0].Hotkey)
+	require.EqualValues(t, 1, stakes[0].Netuid)
+	require.EqualValues(t, 250, stakes[0].Alpha)
+	require.EqualValues(t, 2, stakes[1].Netuid)
+	require.EqualValues(t, 25, stakes[1].Alpha)
+}
+
+func account(b byte) types.AccountID {
+	var acc types.AccountID
+	acc[0] = b
+	return acc
+}
+
+// fixed encodes n as a U64F64
+func fixed(n uint64) typetools.U64F64 {
+	return typetools.U64F64{Bits: types.NewU128(*new(big.Int).Lsh(new(big.Int).SetUint64(n), 64))}
+}
+
+func subtensorKey(t testing.TB, c *client.Client, item string, keys ...[]byte) types.StorageKey {
+	t.Helper()
+	key, err := types.CreateStorageKey(c.Metadata(), "SubtensorModule", item, keys...)
+	require.NoError(t, err)
+	return key
+}
@@ -1,26 +1,26 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/subtrahend-labs/gobt/client"
 )
 
-func getStorageOptionalBlock(c *client.Client, key types.StorageKey, res any, block *types.Hash) error {
-	var err error
-	var ok bool
-	if block == nil {
-		ok, err = c.Api.RPC.State.GetStorageLatest(key, res)
-	} else {
-		ok, err = c.Api.RPC.State.GetStorage(key, res, *block)
-	}
+// getStorageOptionalBlock returns the raw value stored under key, nil if there
+// is none.
+func getStorageOptionalBlock(ctx context.Context, c *client.Client, key types.StorageKey, block *types.Hash) ([]byte, error) {
+	var raw string
+	err := c.CallAt(ctx, &raw, "state_getStorage", block, key.Hex())
 	if err != nil {
-		return fmt.Errorf("failed to get storage: %v", err)
+		return nil, fmt.Errorf("failed to get storage: %w", err)
 	}
 
-	if !ok {
-		return fmt.Errorf("storage not found")
+	bz, err := codec.HexDecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode storage: %w", err)
 	}
-	return nil
+	return bz, nil
 }
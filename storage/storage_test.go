@@ -0,0 +1,107 @@
+package storage_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+func TestGetAccountInfoContext(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	node.Handle("state_getStorage", func([]json.RawMessage) (any, error) {
+		return codec.EncodeToHex(storage.AccountInfo{Nonce: 7})
+	})
+
+	c, err := client.NewClient(node.URL())
+	require.NoError(t, err)
+	defer c.Close()
+
+	info, err := storage.GetAccountInfoContext(context.Background(), c, signature.TestKeyringPairAlice.PublicKey, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, info.Nonce)
+}
+
+func TestGetAccountInfoContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	node := testutils.NewFakeNode(t)
+	node.Handle("state_getStorage", func([]json.RawMessage) (any, error) {
+		<-release
+		return nil, nil
+	})
+
+	c, err := client.NewClient(node.URL())
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = storage.GetAccountInfoContext(ctx, c, signature.TestKeyringPairAlice.PublicKey, nil)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), err.Error())
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestGetAccountInfoInMemory(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	node.Respond("state_getStorage", mustEncodeHex(t, storage.AccountInfo{Nonce: 3}))
+	c := node.NewClient()
+
+	info, err := storage.GetAccountInfo(c, signature.TestKeyringPairAlice.PublicKey, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, info.Nonce)
+}
+
+func mustEncodeHex(t testing.TB, v any) string {
+	t.Helper()
+	enc, err := codec.EncodeToHex(v)
+	require.NoError(t, err)
+	return enc
+}
+
+func mustEncode(t testing.TB, v any) []byte {
+	t.Helper()
+	bz, err := codec.Encode(v)
+	require.NoError(t, err)
+	return bz
+}
+
+// BenchmarkGetAccountInfo reports the RPCs a query costs. "refetch" is how
+// queries used to work, fetching the metadata for every storage key.
+func BenchmarkGetAccountInfo(b *testing.B) {
+	node := testutils.NewFakeNode(b)
+	node.Respond("state_getStorage", mustEncodeHex(b, storage.AccountInfo{Nonce: 1}))
+	c := node.NewClient()
+	ctx := context.Background()
+
+	run := func(b *testing.B, refetch bool) {
+		before := node.Calls()
+		for i := 0; i < b.N; i++ {
+			if refetch {
+				var raw string
+				if err := c.Api.Client.CallContext(ctx, &raw, "state_getMetadata"); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if _, err := storage.GetAccountInfoContext(ctx, c, signature.TestKeyringPairAlice.PublicKey, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(node.Calls()-before)/float64(b.N), "rpcs/op")
+	}
+
+	b.Run("refetch", func(b *testing.B) { run(b, true) })
+	b.Run("cached", func(b *testing.B) { run(b, false) })
+}
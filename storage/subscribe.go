@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+)
+
+// TypedKey is a storage key whose value decodes into T
+type TypedKey[T any] struct {
+	Key types.StorageKey
+}
+
+// NewKey builds the key of pallet.item under keys, like Key, for values of
+// type T.
+func NewKey[T any](c *client.Client, pallet, item string, keys ...[]byte) (TypedKey[T], error) {
+	key, err := Key(c, pallet, item, keys...)
+	if err != nil {
+		return TypedKey[T]{}, err
+	}
+	return TypedKey[T]{Key: key}, nil
+}
+
+// AccountKey is the key of the account of a coldkey
+func AccountKey(c *client.Client, accountID types.AccountID) (TypedKey[AccountInfo], error) {
+	return NewKey[AccountInfo](c, "System", "Account", accountID.ToBytes())
+}
+
+// Change is a new value of a subscribed key
+type Change[T any] struct {
+	// Block is the block the value changed in
+	Block types.Hash
+	Key   types.StorageKey
+	// Old is the previous value, nil for the first value seen and for a
+	// value that did not exist.
+	Old *T
+	// New is nil when the value was removed from an item with no default
+	New *T
+	// Err is set when the new value could not be decoded
+	Err error
+}
+
+// Subscribe follows keys with state_subscribeStorage and calls handle with
+// every value change, one at a time. The current values come first, with a
+// nil Old. The subscription is renewed after connection errors and values
+// that changed in the meantime are reported, once, when it is back. Missing
+// values of items with a default decode from it, as Query does.
+func Subscribe[T any](ctx context.Context, c *client.Client, keys []TypedKey[T], handle func(Change[T])) (*client.Subscription, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no storage keys to subscribe to")
+	}
+
+	w := &watcher[T]{
+		entries: make(map[string]*entry, len(keys)),
+		last:    make(map[string][]byte, len(keys)),
+		values:  make(map[string]*T, len(keys)),
+		handle:  handle,
+	}
+	meta := c.Metadata()
+	hexKeys := make([]string, len(keys))
+	for i, key := range keys {
+		e, err := lookupEntryByKey(meta, key.Key)
+		if err != nil {
+			return nil, err
+		}
+		hexKeys[i] = key.Key.Hex()
+		w.entries[hexKeys[i]] = e
+	}
+
+	sub, err := client.Subscribe(ctx, c, "state", "Storage", "state_storage", []any{hexKeys}, w.notify, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to storage: %w", err)
+	}
+	return sub, nil
+}
+
+// SubscribeChan is Subscribe delivering changes to ch. A change waits for ch
+// to be ready, holding back later ones, until the subscription ends. ch is
+// closed once it has, by Unsubscribe or by closing the client.
+func SubscribeChan[T any](ctx context.Context, c *client.Client, keys []TypedKey[T], ch chan<- Change[T]) (*client.Subscription, error) {
+	var sub *client.Subscription
+	ready := make(chan struct{})
+	defer close(ready)
+
+	sub, err := Subscribe(ctx, c, keys, func(change Change[T]) {
+		<-ready
+		select {
+		case ch <- change:
+		case <-sub.Done():
+		case <-c.Done():
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-sub.Done():
+		case <-c.Done():
+		}
+		// waits for the handler, the only sender, to return
+		sub.Unsubscribe()
+		close(ch)
+	}()
+	return sub, nil
+}
+
+// watcher keeps the last value of every key to report changes only
+type watcher[T any] struct {
+	entries map[string]*entry
+	last    map[string][]byte
+	values  map[string]*T
+	handle  func(Change[T])
+}
+
+func (w *watcher[T]) notify(set types.StorageChangeSet) {
+	for _, kv := range set.Changes {
+		k := kv.StorageKey.Hex()
+		e, ok := w.entries[k]
+		if !ok {
+			continue
+		}
+		var bz []byte
+		if kv.HasStorageData {
+			bz = kv.StorageData
+		}
+		prev, seen := w.last[k]
+		if seen && bytes.Equal(prev, bz) {
+			continue
+		}
+
+		change := Change[T]{Block: set.Block, Key: kv.StorageKey, Old: w.values[k]}
+		var v T
+		switch err := e.decode(bz, &v); {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			change.Err = err
+		default:
+			change.New = &v
+		}
+
+		w.last[k] = bz
+		w.values[k] = change.New
+		w.handle(change)
+	}
+}
@@ -0,0 +1,124 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+)
+
+// storageChange is a state_storage notification setting key to value, or
+// removing it when value is nil.
+func storageChange(t *testing.T, block byte, key types.StorageKey, value any) map[string]any {
+	var v any
+	if value != nil {
+		bz, err := codec.Encode(value)
+		require.NoError(t, err)
+		v = codec.HexEncodeToString(bz)
+	}
+	return map[string]any{
+		"block":   types.Hash{block}.Hex(),
+		"changes": [][]any{{key.Hex(), v}},
+	}
+}
+
+func receive[T any](t *testing.T, ch <-chan storage.Change[T]) storage.Change[T] {
+	t.Helper()
+	select {
+	case change := <-ch:
+		return change
+	case <-time.After(5 * time.Second):
+		t.Fatal("no storage change")
+		return storage.Change[T]{}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient(client.WithBackoff(client.Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, MaxAttempts: 20}))
+
+	tao, err := storage.NewKey[types.U64](c, "SubtensorModule", "SubnetTAO", []byte{1, 0})
+	require.NoError(t, err)
+	other, err := storage.NewKey[types.U64](c, "SubtensorModule", "SubnetTAO", []byte{2, 0})
+	require.NoError(t, err)
+
+	ch := make(chan storage.Change[types.U64])
+	sub, err := storage.SubscribeChan(context.Background(), c, []storage.TypedKey[types.U64]{tao}, ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	node.Notify("state_storage", storageChange(t, 1, tao.Key, types.U64(10)))
+	change := receive(t, ch)
+	require.Equal(t, types.Hash{1}, change.Block)
+	require.Equal(t, tao.Key, change.Key)
+	require.Nil(t, change.Old)
+	require.EqualValues(t, 10, *change.New)
+
+	// unchanged values and other keys are not reported
+	node.Notify("state_storage", storageChange(t, 2, tao.Key, types.U64(10)))
+	node.Notify("state_storage", storageChange(t, 2, other.Key, types.U64(5)))
+	node.Notify("state_storage", storageChange(t, 3, tao.Key, nil))
+	change = receive(t, ch)
+	require.Equal(t, types.Hash{3}, change.Block)
+	require.EqualValues(t, 10, *change.Old)
+	require.EqualValues(t, 0, *change.New, "missing values decode from the default")
+
+	node.Kill()
+	node.Restart()
+	// notify until the subscription is back, repeats are not reported
+	require.Eventually(t, func() bool {
+		node.Notify("state_storage", storageChange(t, 4, tao.Key, types.U64(12)))
+		select {
+		case change = <-ch:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, types.Hash{4}, change.Block)
+	require.EqualValues(t, 0, *change.Old)
+	require.EqualValues(t, 12, *change.New)
+}
+
+func TestSubscribeUnknownKey(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	_, err := storage.Subscribe(context.Background(), c, []storage.TypedKey[types.U64]{{Key: make([]byte, 32)}}, func(storage.Change[types.U64]) {})
+	require.ErrorIs(t, err, storage.ErrUnknownItem)
+	require.Zero(t, node.MethodCalls("state_subscribeStorage"))
+}
+
+func TestSubscribeChanClientClosed(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+
+	tao, err := storage.NewKey[types.U64](c, "SubtensorModule", "SubnetTAO", []byte{1, 0})
+	require.NoError(t, err)
+
+	ch := make(chan storage.Change[types.U64])
+	_, err = storage.SubscribeChan(context.Background(), c, []storage.TypedKey[types.U64]{tao}, ch)
+	require.NoError(t, err)
+
+	// nobody receives, the change holds the handler until the client closes
+	node.Notify("state_storage", storageChange(t, 1, tao.Key, types.U64(10)))
+	c.Close()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("ch was not closed")
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package storage
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/subtrahend-labs/gobt/client"
@@ -10,43 +10,19 @@ import (
 
 // Returns sn tao emission percentage * 1e9
 // block is optional, but more performant if already subscribed to chain
-func GetSubnetTaoInEmission(c *client.Client, netuid types.U16, block *types.Hash) (*types.U64, error) {
-	meta, err := c.Api.RPC.State.GetMetadataLatest()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get metadata: %v", err)
-	}
-
-	storageKey, err := types.CreateStorageKey(meta, "SubtensorModule", "SubnetTaoInEmission", typetools.Uint16ToBytes(uint16(netuid)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create storage key: %v", err)
-	}
-
-	var res types.U64
-	err = getStorageOptionalBlock(c, storageKey, &res, block)
-	if err != nil {
-		return nil, err
-	}
+func GetSubnetTaoInEmission(c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return GetSubnetTaoInEmissionContext(context.Background(), c, netuid, block)
+}
 
-	return &res, nil
+func GetSubnetTaoInEmissionContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*types.U64, error) {
+	return Query[types.U64](ctx, c, "SubtensorModule", "SubnetTaoInEmission", block, typetools.Uint16ToBytes(uint16(netuid)))
 }
 
 // Validator permits per uid
-func GetValidatorPermits(c *client.Client, netuid types.U16, block *types.Hash) (*[]types.Bool, error) {
-	meta, err := c.Api.RPC.State.GetMetadataLatest()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get metadata: %v", err)
-	}
-
-	storageKey, err := types.CreateStorageKey(meta, "SubtensorModule", "ValidatorPermit", typetools.Uint16ToBytes(uint16(netuid)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create storage key: %v", err)
-	}
-
-	var res []types.Bool
-	err = getStorageOptionalBlock(c, storageKey, &res, block)
-	if err != nil {
-		return nil, err
-	}
+func GetValidatorPermits(c *client.Client, netuid types.U16, block *client.Block) (*[]types.Bool, error) {
+	return GetValidatorPermitsContext(context.Background(), c, netuid, block)
+}
 
-	return &res, nil
+func GetValidatorPermitsContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*[]types.Bool, error) {
+	return Query[[]types.Bool](ctx, c, "SubtensorModule", "ValidatorPermit", block, typetools.Uint16ToBytes(uint16(netuid)))
 }
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"math"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+// UidValue is one non-zero entry of a sparse row, a weight or bond toward Uid
+type UidValue struct {
+	Uid   types.U16
+	Value types.U16
+}
+
+// SparseMatrix holds the rows of a subnet's weights or bonds by the uid that
+// set them. Uids without a row have none stored.
+type SparseMatrix struct {
+	Netuid types.U16
+	Rows   map[types.U16][]UidValue
+}
+
+// Size is one past the highest uid found in the matrix, as a row or a column
+func (m *SparseMatrix) Size() int {
+	n := 0
+	for uid, row := range m.Rows {
+		n = max(n, int(uid)+1)
+		for _, e := range row {
+			n = max(n, int(e.Uid)+1)
+		}
+	}
+	return n
+}
+
+// Dense expands the matrix to n rows of n columns, usually the number of
+// neurons of the subnet. Entries beyond n are dropped.
+func (m *SparseMatrix) Dense(n int) [][]uint16 {
+	dense := make([][]uint16, n)
+	for i := range dense {
+		dense[i] = make([]uint16, n)
+	}
+	for uid, row := range m.Rows {
+		if int(uid) >= n {
+			continue
+		}
+		for _, e := range row {
+			if int(e.Uid) < n {
+				dense[uid][e.Uid] = uint16(e.Value)
+			}
+		}
+	}
+	return dense
+}
+
+// Normalized is Dense with values mapped from [0, u16 max] to [0, 1], the
+// scale the runtime normalizes weights and bonds to.
+func (m *SparseMatrix) Normalized(n int) [][]float64 {
+	dense := m.Dense(n)
+	norm := make([][]float64, n)
+	for i, row := range dense {
+		norm[i] = make([]float64, n)
+		for j, v := range row {
+			norm[i][j] = float64(v) / math.MaxUint16
+		}
+	}
+	return norm
+}
+
+// Weights set by a uid, sparse
+func GetWeights(c *client.Client, netuid, uid types.U16, block *client.Block) (*[]UidValue, error) {
+	return GetWeightsContext(context.Background(), c, netuid, uid, block)
+}
+
+func GetWeightsContext(ctx context.Context, c *client.Client, netuid, uid types.U16, block *client.Block) (*[]UidValue, error) {
+	return Query[[]UidValue](ctx, c, "SubtensorModule", "Weights", block, typetools.Uint16ToBytes(uint16(netuid)), typetools.Uint16ToBytes(uint16(uid)))
+}
+
+// Bonds of a uid, sparse
+func GetBonds(c *client.Client, netuid, uid types.U16, block *client.Block) (*[]UidValue, error) {
+	return GetBondsContext(context.Background(), c, netuid, uid, block)
+}
+
+func GetBondsContext(ctx context.Context, c *client.Client, netuid, uid types.U16, block *client.Block) (*[]UidValue, error) {
+	return Query[[]UidValue](ctx, c, "SubtensorModule", "Bonds", block, typetools.Uint16ToBytes(uint16(netuid)), typetools.Uint16ToBytes(uint16(uid)))
+}
+
+// Weights of every uid of a subnet
+// block is optional, nil reads the best block
+func GetWeightsMatrix(c *client.Client, netuid types.U16, block *client.Block) (*SparseMatrix, error) {
+	return GetWeightsMatrixContext(context.Background(), c, netuid, block)
+}
+
+func GetWeightsMatrixContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*SparseMatrix, error) {
+	return getMatrix(ctx, c, "Weights", netuid, block)
+}
+
+// Bonds of every uid of a subnet
+// block is optional, nil reads the best block
+func GetBondsMatrix(c *client.Client, netuid types.U16, block *client.Block) (*SparseMatrix, error) {
+	return GetBondsMatrixContext(context.Background(), c, netuid, block)
+}
+
+func GetBondsMatrixContext(ctx context.Context, c *client.Client, netuid types.U16, block *client.Block) (*SparseMatrix, error) {
+	return getMatrix(ctx, c, "Bonds", netuid, block)
+}
+
+func getMatrix(ctx context.Context, c *client.Client, item string, netuid types.U16, block *client.Block) (*SparseMatrix, error) {
+	opts := []IterOption{WithPrefix(typetools.Uint16ToBytes(uint16(netuid))), WithPageSize(1000)}
+	if block != nil {
+		opts = append(opts, AtBlock(block))
+	}
+	it, err := Iterate[[]UidValue](c, "SubtensorModule", item, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &SparseMatrix{Netuid: netuid, Rows: map[types.U16][]UidValue{}}
+	for it.Next(ctx) {
+		e := it.Entry()
+		var uid types.U16
+		if err := e.DecodeKey(1, &uid); err != nil {
+			return nil, err
+		}
+		if len(e.Value) > 0 {
+			m.Rows[uid] = e.Value
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,46 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/storage"
+	"github.com/subtrahend-labs/gobt/testutils"
+	"github.com/subtrahend-labs/gobt/typetools"
+)
+
+func TestGetWeightsMatrix(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	c := node.NewClient()
+	set := func(item string, netuid, uid uint16, row []storage.UidValue) {
+		node.SetStorage(subtensorKey(t, c, item, typetools.Uint16ToBytes(netuid), typetools.Uint16ToBytes(uid)), row)
+	}
+
+	set("Weights", 1, 0, []storage.UidValue{{Uid: 1, Value: 65535}, {Uid: 2, Value: 32768}})
+	set("Weights", 1, 2, []storage.UidValue{{Uid: 0, Value: 100}})
+	set("Weights", 1, 300, []storage.UidValue{{Uid: 0, Value: 1}})
+	set("Weights", 2, 0, []storage.UidValue{{Uid: 0, Value: 7}})
+	set("Bonds", 1, 1, []storage.UidValue{{Uid: 0, Value: 5}})
+
+	row, err := storage.GetWeights(c, 1, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, *row, 2)
+
+	m, err := storage.GetWeightsMatrix(c, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, m.Rows, 3)
+	require.Equal(t, 301, m.Size())
+
+	dense := m.Dense(3)
+	require.Equal(t, [][]uint16{{0, 65535, 32768}, {0, 0, 0}, {100, 0, 0}}, dense)
+
+	norm := m.Normalized(3)
+	require.Equal(t, 1.0, norm[0][1])
+	require.InDelta(t, 0.5, norm[0][2], 1e-4)
+	require.Zero(t, norm[1][0])
+
+	bonds, err := storage.GetBondsMatrix(c, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, bonds.Rows, 1)
+	require.Equal(t, []storage.UidValue{{Uid: 0, Value: 5}}, bonds.Rows[1])
+}
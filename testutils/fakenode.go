@@ -0,0 +1,495 @@
+package testutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/client"
+	"github.com/subtrahend-labs/gobt/transport"
+)
+
+// Handler answers a JSON-RPC request. The returned value is marshalled as the
+// result, a returned error is sent back as a JSON-RPC error.
+type Handler func(params []json.RawMessage) (any, error)
+
+// FakeNode is a minimal substrate JSON-RPC server for unit tests, reachable
+// over a websocket or in memory through Dialer. It can be killed and
+// restarted on the same address to simulate a node going away.
+type FakeNode struct {
+	t     testing.TB
+	addr  string
+	srv   *httptest.Server
+	calls atomic.Int64
+
+	mu            sync.Mutex
+	killed        bool
+	conns         []io.Closer
+	handlers      map[string]Handler
+	subs          map[string][]subscriber
+	nextSub       int
+	version       types.RuntimeVersion
+	finalized     types.Header
+	finalizedHash types.Hash
+	storage       map[string][]byte
+	methodCalls   map[string]int64
+}
+
+type subscriber struct {
+	id   string
+	send func(any)
+}
+
+// FakeRuntimeVersion is the runtime version a FakeNode reports until changed
+// with SetRuntimeVersion.
+var FakeRuntimeVersion = types.RuntimeVersion{
+	APIs:               []types.RuntimeVersionAPI{},
+	SpecName:           "node-subtensor",
+	ImplName:           "node-subtensor",
+	SpecVersion:        100,
+	TransactionVersion: 1,
+}
+
+// FakeGenesisHash is the genesis hash a FakeNode reports
+var FakeGenesisHash = types.NewHash([]byte("fake subtensor genesis"))
+
+// NewFakeNode starts a fake node that serves FakeMetadata, the runtime
+// version and the finalized head, including their subscriptions, block
+// hashes and the storage set with SetStorage. The
+// genesis block, hashed to FakeGenesisHash, is finalized until
+// SetFinalizedHead is called. Other methods must be registered with Handle.
+func NewFakeNode(t testing.TB) *FakeNode {
+	n := &FakeNode{
+		t:             t,
+		subs:          map[string][]subscriber{},
+		version:       FakeRuntimeVersion,
+		finalizedHash: FakeGenesisHash,
+		storage:       map[string][]byte{},
+		methodCalls:   map[string]int64{},
+	}
+	n.handlers = map[string]Handler{
+		"state_getMetadata": func([]json.RawMessage) (any, error) {
+			return FakeMetadataHex(), nil
+		},
+		"chain_getBlockHash": func(params []json.RawMessage) (any, error) {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			var number uint64
+			if len(params) > 0 && json.Unmarshal(params[0], &number) == nil && number != 0 && number == uint64(n.finalized.Number) {
+				return n.finalizedHash.Hex(), nil
+			}
+			return FakeGenesisHash.Hex(), nil
+		},
+		"chain_getFinalizedHead": func([]json.RawMessage) (any, error) {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			return n.finalizedHash.Hex(), nil
+		},
+		"chain_getHeader": func([]json.RawMessage) (any, error) {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			return n.finalized, nil
+		},
+		"state_getRuntimeVersion": func([]json.RawMessage) (any, error) {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			return n.version, nil
+		},
+		"state_getStorage":     n.getStorage,
+		"state_getKeysPaged":   n.getKeysPaged,
+		"state_queryStorageAt": n.queryStorageAt,
+	}
+	n.start("127.0.0.1:0")
+	t.Cleanup(n.Kill)
+	return n
+}
+
+func (n *FakeNode) URL() string {
+	return "ws://" + n.addr
+}
+
+// HTTPURL is the endpoint for plain HTTP JSON-RPC, which has no subscriptions.
+func (n *FakeNode) HTTPURL() string {
+	return "http://" + n.addr
+}
+
+// Calls returns the number of requests received so far
+func (n *FakeNode) Calls() int64 {
+	return n.calls.Load()
+}
+
+// MethodCalls returns the number of requests for method received so far
+func (n *FakeNode) MethodCalls(method string) int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.methodCalls[method]
+}
+
+func (n *FakeNode) Handle(method string, h Handler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[method] = h
+}
+
+// Respond makes method always answer with result, whatever the params.
+func (n *FakeNode) Respond(method string, result any) {
+	n.Handle(method, func([]json.RawMessage) (any, error) {
+		return result, nil
+	})
+}
+
+// Dialer connects to the node in memory, without going through the network.
+// Every dial opens a new connection, so Kill and Restart work as they do
+// over websockets.
+func (n *FakeNode) Dialer() transport.Dialer {
+	return func(ctx context.Context, endpoint string) (transport.Transport, error) {
+		n.mu.Lock()
+		if n.killed {
+			n.mu.Unlock()
+			return nil, fmt.Errorf("dial %s: %w", endpoint, syscall.ECONNREFUSED)
+		}
+		local, remote := net.Pipe()
+		n.conns = append(n.conns, remote)
+		n.mu.Unlock()
+
+		dec := json.NewDecoder(remote)
+		enc := json.NewEncoder(remote)
+		go n.serve(dec.Decode, enc.Encode)
+		return transport.NewStream(ctx, endpoint, local)
+	}
+}
+
+// NewClient connects a client to the node in memory. The client is closed
+// when the test ends.
+func (n *FakeNode) NewClient(opts ...client.Option) *client.Client {
+	opts = append([]client.Option{client.WithDialer(n.Dialer())}, opts...)
+	c, err := client.NewClient("memory://"+n.addr, opts...)
+	require.NoError(n.t, err)
+	n.t.Cleanup(c.Close)
+	return c
+}
+
+// SetRuntimeVersion changes the reported runtime version and notifies
+// runtime version subscribers, as a runtime upgrade would.
+func (n *FakeNode) SetRuntimeVersion(rv types.RuntimeVersion) {
+	n.mu.Lock()
+	n.version = rv
+	n.mu.Unlock()
+	n.Notify("state_runtimeVersion", rv)
+}
+
+// SetFinalizedHead makes the node report the block with the given number and
+// hash as finalized and notifies finalized head subscribers. Until called, the
+// genesis block is the finalized head. Any other block number hashes to
+// FakeGenesisHash.
+func (n *FakeNode) SetFinalizedHead(number uint64, hash types.Hash) {
+	header := types.Header{Number: types.BlockNumber(number)}
+	n.mu.Lock()
+	n.finalized = header
+	n.finalizedHash = hash
+	n.mu.Unlock()
+	n.Notify("chain_finalizedHead", header)
+}
+
+// SetStorage stores the SCALE encoding of value under key, or removes key if
+// value is nil. The block hash of storage queries is ignored.
+func (n *FakeNode) SetStorage(key types.StorageKey, value any) {
+	var bz []byte
+	if value != nil {
+		var err error
+		bz, err = codec.Encode(value)
+		require.NoError(n.t, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if bz == nil {
+		delete(n.storage, key.Hex())
+		return
+	}
+	n.storage[key.Hex()] = bz
+}
+
+// Notify pushes result to every subscription whose notifications are sent
+// as method, e.g. "chain_finalizedHead".
+func (n *FakeNode) Notify(method string, result any) {
+	n.mu.Lock()
+	subs := append([]subscriber(nil), n.subs[method]...)
+	n.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.send(rpcNotification{
+			Version: "2.0",
+			Method:  method,
+			Params:  notificationParams{Subscription: sub.id, Result: result},
+		})
+	}
+}
+
+// Kill stops the server and drops every open connection
+func (n *FakeNode) Kill() {
+	n.mu.Lock()
+	n.killed = true
+	srv := n.srv
+	n.mu.Unlock()
+
+	// Stop accepting first so that clients cannot reconnect in between
+	srv.Close()
+
+	n.mu.Lock()
+	for _, c := range n.conns {
+		c.Close()
+	}
+	n.conns = nil
+	n.subs = map[string][]subscriber{}
+	n.mu.Unlock()
+}
+
+// Restart brings a killed node back up on the same address
+func (n *FakeNode) Restart() {
+	n.start(n.addr)
+}
+
+func (n *FakeNode) start(addr string) {
+	l, err := net.Listen("tcp", addr)
+	require.NoError(n.t, err)
+	if n.addr == "" {
+		n.addr = l.Addr().String()
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !websocket.IsWebSocketUpgrade(r) {
+			n.serveHTTP(w, r)
+			return
+		}
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		n.mu.Lock()
+		if n.killed {
+			n.mu.Unlock()
+			ws.Close()
+			return
+		}
+		n.conns = append(n.conns, ws)
+		n.mu.Unlock()
+		n.serve(ws.ReadJSON, ws.WriteJSON)
+	}))
+	srv.Listener = l
+	srv.Start()
+
+	n.mu.Lock()
+	n.srv = srv
+	n.killed = false
+	n.mu.Unlock()
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type notificationParams struct {
+	Subscription string `json:"subscription"`
+	Result       any    `json:"result"`
+}
+
+type rpcNotification struct {
+	Version string             `json:"jsonrpc"`
+	Method  string             `json:"method"`
+	Params  notificationParams `json:"params"`
+}
+
+// subscriptions maps substrate subscribe methods to the method their
+// notifications are sent with and the value pushed right after subscribing.
+var subscriptions = map[string]struct {
+	notification string
+	initial      string
+}{
+	"state_subscribeRuntimeVersion": {"state_runtimeVersion", "state_getRuntimeVersion"},
+	"chain_subscribeFinalizedHeads": {"chain_finalizedHead", "chain_getHeader"},
+	"chain_subscribeNewHeads":       {"chain_newHead", ""},
+	"state_subscribeStorage":        {"state_storage", ""},
+}
+
+func (n *FakeNode) serve(read, write func(any) error) {
+	var writeMu sync.Mutex
+	send := func(v any) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_ = write(v)
+	}
+
+	for {
+		var req rpcRequest
+		if err := read(&req); err != nil {
+			return
+		}
+		n.count(req.Method)
+
+		go func() {
+			if sub, ok := subscriptions[req.Method]; ok {
+				n.subscribe(req, sub.notification, sub.initial, send)
+				return
+			}
+			send(n.answer(req))
+		}()
+	}
+}
+
+func (n *FakeNode) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	n.count(req.Method)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(n.answer(req))
+}
+
+func (n *FakeNode) count(method string) {
+	n.calls.Add(1)
+	n.mu.Lock()
+	n.methodCalls[method]++
+	n.mu.Unlock()
+}
+
+func (n *FakeNode) subscribe(req rpcRequest, notification, initial string, send func(any)) {
+	n.mu.Lock()
+	n.nextSub++
+	id := fmt.Sprintf("sub-%d", n.nextSub)
+	n.subs[notification] = append(n.subs[notification], subscriber{id: id, send: send})
+	n.mu.Unlock()
+
+	send(rpcResponse{Version: "2.0", ID: req.ID, Result: id})
+
+	if initial != "" {
+		resp := n.answer(rpcRequest{Method: initial})
+		if resp.Error == nil {
+			send(rpcNotification{
+				Version: "2.0",
+				Method:  notification,
+				Params:  notificationParams{Subscription: id, Result: resp.Result},
+			})
+		}
+	}
+}
+
+func (n *FakeNode) getStorage(params []json.RawMessage) (any, error) {
+	var key string
+	if len(params) == 0 || json.Unmarshal(params[0], &key) != nil {
+		return nil, fmt.Errorf("invalid storage key")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	bz, ok := n.storage[key]
+	if !ok {
+		return nil, nil
+	}
+	return codec.HexEncodeToString(bz), nil
+}
+
+func (n *FakeNode) getKeysPaged(params []json.RawMessage) (any, error) {
+	var prefix, start string
+	var count int
+	if len(params) < 2 || json.Unmarshal(params[0], &prefix) != nil || json.Unmarshal(params[1], &count) != nil {
+		return nil, fmt.Errorf("invalid params")
+	}
+	if len(params) > 2 {
+		_ = json.Unmarshal(params[2], &start)
+	}
+
+	n.mu.Lock()
+	keys := make([]string, 0, len(n.storage))
+	for k := range n.storage {
+		if strings.HasPrefix(k, prefix) && k > start {
+			keys = append(keys, k)
+		}
+	}
+	n.mu.Unlock()
+
+	sort.Strings(keys)
+	if len(keys) > count {
+		keys = keys[:count]
+	}
+	return keys, nil
+}
+
+func (n *FakeNode) queryStorageAt(params []json.RawMessage) (any, error) {
+	var keys []string
+	if len(params) == 0 || json.Unmarshal(params[0], &keys) != nil {
+		return nil, fmt.Errorf("invalid storage keys")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	changes := make([][]any, len(keys))
+	for i, k := range keys {
+		changes[i] = []any{k, nil}
+		if bz, ok := n.storage[k]; ok {
+			changes[i][1] = codec.HexEncodeToString(bz)
+		}
+	}
+	return []any{map[string]any{"block": n.finalizedHash.Hex(), "changes": changes}}, nil
+}
+
+func (n *FakeNode) answer(req rpcRequest) rpcResponse {
+	resp := rpcResponse{Version: "2.0", ID: req.ID}
+
+	if strings.Contains(req.Method, "_unsubscribe") {
+		resp.Result = true
+		return resp
+	}
+
+	n.mu.Lock()
+	h, ok := n.handlers[req.Method]
+	n.mu.Unlock()
+	if !ok {
+		resp.Error = &rpcError{Code: -32601, Message: fmt.Sprintf("Method not found: %s", req.Method)}
+		return resp
+	}
+
+	res, err := h(req.Params)
+	if err != nil {
+		resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+		return resp
+	}
+	// a missing result is how nodes answer empty storage
+	if res == nil {
+		res = json.RawMessage("null")
+	}
+	resp.Result = res
+	return resp
+}
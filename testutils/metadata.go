@@ -0,0 +1,207 @@
+package testutils
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var (
+	fakeMetadataOnce sync.Once
+	fakeMetadataHex  string
+)
+
+// FakeMetadataHex is the metadata a FakeNode serves: the gsrpc example
+// metadata plus a SubtensorModule pallet declaring the storage items gobt
+// reads, with the same hashers, key and value types as subtensor.
+func FakeMetadataHex() string {
+	fakeMetadataOnce.Do(func() {
+		meta, err := fakeMetadata()
+		if err != nil {
+			panic(err)
+		}
+		fakeMetadataHex, err = codec.EncodeToHex(meta)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return fakeMetadataHex
+}
+
+// FakeMetadata decodes FakeMetadataHex
+func FakeMetadata() *types.Metadata {
+	var meta types.Metadata
+	if err := codec.DecodeFromHex(FakeMetadataHex(), &meta); err != nil {
+		panic(err)
+	}
+	return &meta
+}
+
+func fakeMetadata() (*types.Metadata, error) {
+	var meta types.Metadata
+	if err := codec.DecodeFromHex(types.MetadataV14Data, &meta); err != nil {
+		return nil, err
+	}
+	reg := &typeRegistry{types: &meta.AsMetadataV14.Lookup}
+	for _, t := range reg.types.Types {
+		reg.next = max(reg.next, t.ID.Int64()+1)
+	}
+
+	u8 := reg.primitive(types.IsU8)
+	u16 := reg.primitive(types.IsU16)
+	u64 := reg.primitive(types.IsU64)
+	u128 := reg.primitive(types.IsU128)
+	boolean := reg.primitive(types.IsBool)
+	accountID := reg.composite(reg.array(32, reg.primitive(types.IsU8)))
+	u64f64 := reg.composite(u128)
+	i96f32 := reg.composite(reg.primitive(types.IsI128))
+
+	blake := types.StorageHasherV10{IsBlake2_128Concat: true}
+	identity := types.StorageHasherV10{IsIdentity: true}
+	netuidMap := func(name string, value int64, fallback any) types.StorageEntryMetadataV14 {
+		return storageMap(name, []types.StorageHasherV10{identity}, u16, value, fallback)
+	}
+
+	sparseRow := reg.sequence(reg.tuple(u16, u16))
+
+	items := []types.StorageEntryMetadataV14{
+		storageMap("Weights", []types.StorageHasherV10{identity, identity}, reg.tuple(u16, u16), sparseRow, []struct{ A, B types.U16 }{}),
+		storageMap("Bonds", []types.StorageHasherV10{identity, identity}, reg.tuple(u16, u16), sparseRow, []struct{ A, B types.U16 }{}),
+		netuidMap("SubnetTaoInEmission", u64, types.U64(0)),
+		netuidMap("ValidatorPermit", reg.sequence(boolean), []types.Bool{}),
+		netuidMap("SubnetTAO", u64, types.U64(0)),
+		netuidMap("SubnetAlphaIn", u64, types.U64(0)),
+		netuidMap("SubnetAlphaOut", u64, types.U64(0)),
+		storageMap("Alpha", []types.StorageHasherV10{blake, blake, identity}, reg.tuple(accountID, accountID, u16), u64f64, types.NewU128(*big.NewInt(0))),
+		storageMap("TotalHotkeyAlpha", []types.StorageHasherV10{blake, identity}, reg.tuple(accountID, u16), u64, types.U64(0)),
+		storageMap("TotalHotkeyShares", []types.StorageHasherV10{blake, identity}, reg.tuple(accountID, u16), u64f64, types.NewU128(*big.NewInt(0))),
+		storageMap("StakingHotkeys", []types.StorageHasherV10{blake}, accountID, reg.sequence(accountID), []types.AccountID{}),
+		storageMap("OwnedHotkeys", []types.StorageHasherV10{blake}, accountID, reg.sequence(accountID), []types.AccountID{}),
+		netuidMap("Tempo", u16, types.U16(0)),
+		netuidMap("Kappa", u16, types.U16(0)),
+		netuidMap("Rho", u16, types.U16(0)),
+		netuidMap("ImmunityPeriod", u16, types.U16(0)),
+		netuidMap("MinAllowedWeights", u16, types.U16(0)),
+		netuidMap("MaxWeightsLimit", u16, types.U16(0)),
+		netuidMap("WeightsVersionKey", u64, types.U64(0)),
+		netuidMap("WeightsSetRateLimit", u64, types.U64(0)),
+		netuidMap("ActivityCutoff", u16, types.U16(0)),
+		netuidMap("MaxAllowedUids", u16, types.U16(0)),
+		netuidMap("MaxAllowedValidators", u16, types.U16(0)),
+		netuidMap("Burn", u64, types.U64(0)),
+		netuidMap("MinBurn", u64, types.U64(0)),
+		netuidMap("MaxBurn", u64, types.U64(0)),
+		netuidMap("Difficulty", u64, types.U64(0)),
+		netuidMap("MinDifficulty", u64, types.U64(0)),
+		netuidMap("MaxDifficulty", u64, types.U64(0)),
+		netuidMap("ServingRateLimit", u64, types.U64(0)),
+		netuidMap("AdjustmentInterval", u16, types.U16(0)),
+		netuidMap("AdjustmentAlpha", u64, types.U64(0)),
+		netuidMap("TargetRegistrationsPerInterval", u16, types.U16(0)),
+		netuidMap("MaxRegistrationsPerBlock", u16, types.U16(0)),
+		netuidMap("NetworkRegistrationAllowed", boolean, types.Bool(false)),
+		netuidMap("NetworkPowRegistrationAllowed", boolean, types.Bool(false)),
+		netuidMap("BondsMovingAverage", u64, types.U64(0)),
+		netuidMap("BondsPenalty", u16, types.U16(0)),
+		netuidMap("EMAPriceHalvingBlocks", u64, types.U64(0)),
+		netuidMap("SubnetOwnerHotkey", accountID, types.AccountID{}),
+		// not a subtensor item: a map with a variable size key before the
+		// last one, which needs its length prefix to split keys
+		storageMap("TestNamedStake", []types.StorageHasherV10{blake, identity}, reg.tuple(reg.sequence(u8), u16), u64, types.U64(0)),
+		storageValue("NetworkRateLimit", u64, types.U64(0)),
+		storageValue("TxRateLimit", u64, types.U64(0)),
+		storageValue("MaxDelegateTake", u16, types.U16(0)),
+		storageValue("SubnetOwnerCut", u16, types.U16(0)),
+		storageValue("SubnetMovingAlpha", i96f32, types.NewU128(*big.NewInt(0))),
+	}
+	meta.AsMetadataV14.Pallets = append(meta.AsMetadataV14.Pallets, types.PalletMetadataV14{
+		Name:       "SubtensorModule",
+		HasStorage: true,
+		Storage:    types.StorageMetadataV14{Prefix: "SubtensorModule", Items: items},
+		Index:      250,
+	})
+	return &meta, nil
+}
+
+func storageMap(name string, hashers []types.StorageHasherV10, key, value int64, fallback any) types.StorageEntryMetadataV14 {
+	bz, err := codec.Encode(fallback)
+	if err != nil {
+		panic(err)
+	}
+	return types.StorageEntryMetadataV14{
+		Name:     types.Text(name),
+		Modifier: types.StorageFunctionModifierV0{IsDefault: true},
+		Type: types.StorageEntryTypeV14{
+			IsMap: true,
+			AsMap: types.MapTypeV14{
+				Hashers: hashers,
+				Key:     types.NewSi1LookupTypeIDFromUInt(uint64(key)),
+				Value:   types.NewSi1LookupTypeIDFromUInt(uint64(value)),
+			},
+		},
+		Fallback: bz,
+	}
+}
+
+func storageValue(name string, value int64, fallback any) types.StorageEntryMetadataV14 {
+	bz, err := codec.Encode(fallback)
+	if err != nil {
+		panic(err)
+	}
+	return types.StorageEntryMetadataV14{
+		Name:     types.Text(name),
+		Modifier: types.StorageFunctionModifierV0{IsDefault: true},
+		Type:     types.StorageEntryTypeV14{IsPlainType: true, AsPlainType: lookupID(value)},
+		Fallback: bz,
+	}
+}
+
+// typeRegistry adds types to the type registry of a metadata
+type typeRegistry struct {
+	types *types.PortableRegistryV14
+	next  int64
+}
+
+func (r *typeRegistry) add(def types.Si1TypeDef) int64 {
+	id := r.next
+	r.next++
+	r.types.Types = append(r.types.Types, types.PortableTypeV14{
+		ID:   types.NewSi1LookupTypeIDFromUInt(uint64(id)),
+		Type: types.Si1Type{Def: def},
+	})
+	return id
+}
+
+func (r *typeRegistry) primitive(p types.Si0TypeDefPrimitive) int64 {
+	return r.add(types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}})
+}
+
+func (r *typeRegistry) array(n uint32, elem int64) int64 {
+	return r.add(types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: types.U32(n), Type: lookupID(elem)}})
+}
+
+func (r *typeRegistry) sequence(elem int64) int64 {
+	return r.add(types.Si1TypeDef{IsSequence: true, Sequence: types.Si1TypeDefSequence{Type: lookupID(elem)}})
+}
+
+func (r *typeRegistry) tuple(elems ...int64) int64 {
+	tuple := make(types.Si1TypeDefTuple, len(elems))
+	for i, e := range elems {
+		tuple[i] = lookupID(e)
+	}
+	return r.add(types.Si1TypeDef{IsTuple: true, Tuple: tuple})
+}
+
+func (r *typeRegistry) composite(fields ...int64) int64 {
+	def := types.Si1TypeDef{IsComposite: true}
+	for _, f := range fields {
+		def.Composite.Fields = append(def.Composite.Fields, types.Si1Field{Type: lookupID(f)})
+	}
+	return r.add(def)
+}
+
+func lookupID(id int64) types.Si1LookupTypeID {
+	return types.NewSi1LookupTypeIDFromUInt(uint64(id))
+}
@@ -151,7 +151,7 @@ func SignAndSubmit(t *testing.T, cl *client.Client, ext *extrinsic.Extrinsic, si
 	// Sign the extrinsic
 	err = ext.Sign(
 		signer,
-		cl.Meta,
+		cl.Metadata(),
 		ops...,
 	)
 	require.NoError(t, err, "Failed to sign extrinsic")
@@ -185,7 +185,7 @@ func SignAndSubmit(t *testing.T, cl *client.Client, ext *extrinsic.Extrinsic, si
 	events, err := evtr.GetEvents(blockHash)
 	require.NoError(t, err)
 
-	if derr := ExtractDispatchError(*cl.Meta, events); derr != nil {
+	if derr := ExtractDispatchError(*cl.Metadata(), events); derr != nil {
 		t.Fatalf("extrinsic dispatch failed: %v", derr)
 	}
 
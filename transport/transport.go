@@ -0,0 +1,146 @@
+// Package transport provides the connections a client.Client talks to a
+// subtensor node through.
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+
+	gsrpcclient "github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/gorilla/websocket"
+)
+
+// Transport carries JSON-RPC calls and subscriptions to a node. It is the
+// client interface gsrpc builds its RPC modules on.
+type Transport interface {
+	gsrpcclient.Client
+}
+
+// Dialer opens a Transport to endpoint. The client calls it when connecting
+// and again for every reconnect.
+type Dialer func(ctx context.Context, endpoint string) (Transport, error)
+
+// Dial picks the transport from the scheme of endpoint: ws and wss use a
+// websocket, http and https use plain HTTP JSON-RPC.
+func Dial(ctx context.Context, endpoint string) (Transport, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+		return DialWebsocket(ctx, endpoint)
+	case "http", "https":
+		return DialHTTP(ctx, endpoint)
+	default:
+		return nil, fmt.Errorf("no transport for URL scheme %q", u.Scheme)
+	}
+}
+
+// DialWebsocket connects over a websocket, which supports subscriptions. The
+// transport does not redial when the connection drops: calls fail with the
+// websocket's error and the client reconnects.
+func DialWebsocket(ctx context.Context, endpoint string) (Transport, error) {
+	ws, resp, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("%w (HTTP status %s)", err, resp.Status)
+		}
+		return nil, err
+	}
+	return NewStream(ctx, endpoint, &wsStream{conn: ws})
+}
+
+// wsStream carries JSON-RPC over a websocket as a stream, one text message
+// per write. gethrpc's own websocket client redials by itself and reports a
+// failed redial with an unexported error, which the client could not tell
+// apart from other failures.
+type wsStream struct {
+	conn *websocket.Conn
+	r    io.Reader
+	wmu  sync.Mutex
+}
+
+func (s *wsStream) Read(p []byte) (int, error) {
+	for {
+		if s.r == nil {
+			_, r, err := s.conn.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			s.r = r
+		}
+		n, err := s.r.Read(p)
+		if err == io.EOF {
+			s.r = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (s *wsStream) Write(p []byte) (int, error) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	if err := s.conn.WriteMessage(websocket.TextMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *wsStream) Close() error {
+	return s.conn.Close()
+}
+
+// DialHTTP uses one HTTP request per call. Subscriptions fail with
+// gethrpc.ErrNotificationsUnsupported, so the client cannot follow runtime
+// upgrades as they happen and only notices them after a reconnect.
+func DialHTTP(_ context.Context, endpoint string) (Transport, error) {
+	cl, err := gethrpc.DialHTTP(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcClient{Client: cl, url: endpoint}, nil
+}
+
+// rpcClient adapts a gethrpc client to the gsrpc client interface
+type rpcClient struct {
+	*gethrpc.Client
+	url string
+}
+
+func (c *rpcClient) URL() string {
+	return c.url
+}
+
+// NewStream runs JSON-RPC over an already established stream, such as one end
+// of a net.Pipe. Closing the transport closes the stream.
+func NewStream(ctx context.Context, name string, stream io.ReadWriteCloser) (Transport, error) {
+	cl, err := gethrpc.DialIO(ctx, stream, stream)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+	return &streamClient{rpcClient: rpcClient{Client: cl, url: name}, stream: stream}, nil
+}
+
+// streamClient closes its stream along with the client, which gethrpc does
+// not do for IO connections.
+type streamClient struct {
+	rpcClient
+	stream io.Closer
+}
+
+func (c *streamClient) Close() {
+	// The stream goes first: gethrpc waits for its read loop, which only
+	// returns once the stream fails.
+	_ = c.stream.Close()
+	c.Client.Close()
+}
@@ -0,0 +1,40 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/subtrahend-labs/gobt/testutils"
+	"github.com/subtrahend-labs/gobt/transport"
+)
+
+func TestDialUnknownScheme(t *testing.T) {
+	_, err := transport.Dial(context.Background(), "ftp://127.0.0.1:9944")
+	require.ErrorContains(t, err, `"ftp"`)
+}
+
+func TestWebsocketConnectionLost(t *testing.T) {
+	node := testutils.NewFakeNode(t)
+	tr, err := transport.DialWebsocket(context.Background(), node.URL())
+	require.NoError(t, err)
+	defer tr.Close()
+
+	var hash string
+	require.NoError(t, tr.Call(&hash, "chain_getBlockHash"))
+
+	node.Kill()
+	node.Restart()
+	// the call noticing the drop and every later one fail with the
+	// websocket's own errors, even with the node back: the transport leaves
+	// redialing to the client
+	for range 3 {
+		err = tr.Call(&hash, "chain_getBlockHash")
+		var closeErr *websocket.CloseError
+		var netErr net.Error
+		require.True(t, errors.As(err, &closeErr) || errors.As(err, &netErr), "%T: %v", err, err)
+	}
+}
@@ -0,0 +1,40 @@
+package typetools
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// U64F64 represents a fixed-point number with 64 bits integer part and 64 bits fractional part
+type U64F64 struct {
+	Bits types.U128
+}
+
+// Float64 converts the fixed-point value, losing precision past 53 bits
+func (f U64F64) Float64() float64 {
+	if f.Bits.Int == nil {
+		return 0
+	}
+	v, _ := new(big.Float).SetInt(f.Bits.Int).Float64()
+	return v / (1 << 64)
+}
+
+// I96F32 represents a fixed-point number with 96 bits integer part and 32 bits fractional part
+type I96F32 struct {
+	Bits types.U128
+}
+
+// Float64 converts the fixed-point value, losing precision past 53 bits. The
+// bits are two's complement, so a set top bit makes the value negative.
+func (f I96F32) Float64() float64 {
+	if f.Bits.Int == nil {
+		return 0
+	}
+	n := f.Bits.Int
+	if n.Bit(127) == 1 {
+		n = new(big.Int).Sub(n, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	v, _ := new(big.Float).SetInt(n).Float64()
+	return v / (1 << 32)
+}
@@ -14,7 +14,17 @@ func Uint16ToBytes(n uint16) []byte {
 	return b
 }
 
+// AccountIDToSS58 always uses the generic substrate prefix 42.
+//
+// Deprecated: use client.Client.SS58 to format with the prefix of the
+// client's network, or AccountIDToSS58WithPrefix.
 func AccountIDToSS58(acc types.AccountID) string {
-	recipientSS58 := subkey.SS58Encode(acc.ToBytes(), 42)
-	return recipientSS58
+	return AccountIDToSS58WithPrefix(acc, 42)
+}
+
+// AccountIDToSS58WithPrefix formats an account as SS58 with the network
+// prefix, such as client.Network.SS58Prefix. client.Client.SS58 calls it with
+// the prefix of the client's network.
+func AccountIDToSS58WithPrefix(acc types.AccountID, prefix uint16) string {
+	return subkey.SS58Encode(acc.ToBytes(), prefix)
 }